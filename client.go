@@ -6,27 +6,39 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
+	"reflect"
 	"time"
 )
 
 // Config represents the configuration for İyzipay client
 type Config struct {
-	APIKey     string
-	SecretKey  string
-	BaseURL    string
-	HTTPClient *http.Client
+	APIKey           string
+	SecretKey        string
+	BaseURL          string
+	HTTPClient       *http.Client
+	RetryPolicy      *RetryPolicy
+	IdempotencyStore IdempotencyStore
+	// VerifySignatures makes signed responses fail with ErrSignatureMismatch
+	// when their signature is missing or invalid
+	VerifySignatures bool
+	// Logger logs every service call with card and identity data redacted
+	Logger *slog.Logger
 }
 
 // Client represents the İyzipay API client
 type Client struct {
-	config *Config
+	config     *Config
+	middleware []Middleware
 
 	// Services
 	APITest                    *APITestService
 	Payment                    *PaymentService
 	BasicPayment               *BasicPaymentService
+	PreAuth                    *PreAuthService
+	PostAuth                   *PostAuthService
 	ThreedsInitialize          *ThreedsInitializeService
 	ThreedsPayment             *ThreedsPaymentService
 	CheckoutForm               *CheckoutFormService
@@ -36,6 +48,7 @@ type Client struct {
 	SubMerchant                *SubMerchantService
 	BKM                        *BKMService
 	APM                        *APMService
+	Pecco                      *PeccoService
 	Subscription               *SubscriptionService
 	InstallmentInfo            *InstallmentInfoService
 	BinNumber                  *BinNumberService
@@ -44,6 +57,7 @@ type Client struct {
 	RefundToBalance            *RefundToBalanceService
 	SettlementToBalance        *SettlementToBalanceService
 	UniversalCardStorage       *UniversalCardStorageService
+	Reporting                  *ReportingService
 }
 
 // NewClient creates a new İyzipay client with the given configuration
@@ -54,6 +68,10 @@ func NewClient(config *Config) *Client {
 		}
 	}
 
+	if config.RetryPolicy == nil {
+		config.RetryPolicy = DefaultRetryPolicy()
+	}
+
 	if err := validateConfig(config); err != nil {
 		panic(fmt.Sprintf("invalid config: %v", err))
 	}
@@ -66,6 +84,8 @@ func NewClient(config *Config) *Client {
 	client.APITest = &APITestService{client: client}
 	client.Payment = &PaymentService{client: client}
 	client.BasicPayment = &BasicPaymentService{client: client}
+	client.PreAuth = &PreAuthService{client: client}
+	client.PostAuth = &PostAuthService{client: client}
 	client.ThreedsInitialize = &ThreedsInitializeService{client: client}
 	client.ThreedsPayment = &ThreedsPaymentService{client: client}
 	client.CheckoutForm = &CheckoutFormService{client: client}
@@ -75,6 +95,7 @@ func NewClient(config *Config) *Client {
 	client.SubMerchant = &SubMerchantService{client: client}
 	client.BKM = &BKMService{client: client}
 	client.APM = &APMService{client: client}
+	client.Pecco = &PeccoService{client: client}
 	client.Subscription = &SubscriptionService{client: client}
 	client.InstallmentInfo = &InstallmentInfoService{client: client}
 	client.BinNumber = &BinNumberService{client: client}
@@ -83,6 +104,7 @@ func NewClient(config *Config) *Client {
 	client.RefundToBalance = &RefundToBalanceService{client: client}
 	client.SettlementToBalance = &SettlementToBalanceService{client: client}
 	client.UniversalCardStorage = &UniversalCardStorageService{client: client}
+	client.Reporting = &ReportingService{client: client}
 
 	return client
 }
@@ -138,7 +160,7 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 
 	// Set both v1 and v2 authorization headers
 	authV1 := generateAuthorizationHeaderV1(c.config.APIKey, randomString, c.config.SecretKey, pkiString)
-	authV2 := generateAuthorizationHeaderV2(c.config.APIKey, randomString, c.config.SecretKey, endpoint, body)
+	authV2 := generateAuthorizationHeaderV2(c.config.APIKey, randomString, c.config.SecretKey, stripQuery(endpoint), body)
 	
 	req.Header.Set(HeaderAuthorization, authV2)
 	req.Header.Set(HeaderAuthorizationFallback, authV1)
@@ -146,8 +168,55 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 	return c.config.HTTPClient.Do(req)
 }
 
-// doRequest performs the request and handles the response
-func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
+// doRequest performs the service call named operation through the middleware chain.
+// It returns an *APIError for HTTP error statuses and for responses whose status is not "success".
+func (c *Client) doRequest(ctx context.Context, operation, method, endpoint string, body interface{}, result interface{}) error {
+	op := &Operation{Name: operation, Method: method, Endpoint: endpoint}
+	return c.intercept(ctx, op, body, result, func(ctx context.Context) error {
+		return c.send(ctx, method, endpoint, body, result)
+	})
+}
+
+// doRequestWithParams fills the path placeholders of endpoint from params and performs the call.
+// Invalid params are reported through the middleware chain like any other failed call.
+func (c *Client) doRequestWithParams(ctx context.Context, operation, method, endpoint string, params map[string]string, body interface{}, result interface{}) error {
+	path, err := buildEndpoint(endpoint, params)
+	if err != nil {
+		return c.reject(ctx, operation, method, endpoint, body, result, err)
+	}
+	return c.doRequest(ctx, operation, method, path, body, result)
+}
+
+// reject reports err, found before the request was sent, through the middleware chain
+func (c *Client) reject(ctx context.Context, operation, method, endpoint string, body interface{}, result interface{}, err error) error {
+	op := &Operation{Name: operation, Method: method, Endpoint: endpoint}
+	return c.intercept(ctx, op, body, result, func(ctx context.Context) error {
+		return err
+	})
+}
+
+// send performs the request, retrying transient failures according to the retry policy
+func (c *Client) send(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
+	for attempt := 1; ; attempt++ {
+		err := c.doRequestOnce(ctx, method, endpoint, body, result)
+		if err == nil || !c.shouldRetry(ctx, method, endpoint, attempt, err) {
+			return err
+		}
+
+		if err := sleep(ctx, c.config.RetryPolicy.backoff(attempt)); err != nil {
+			return err
+		}
+
+		// Drop fields decoded from the failed attempt
+		if result != nil {
+			v := reflect.ValueOf(result).Elem()
+			v.Set(reflect.Zero(v.Type()))
+		}
+	}
+}
+
+// doRequestOnce performs a single attempt of the request and handles the response
+func (c *Client) doRequestOnce(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
 	resp, err := c.makeRequest(ctx, method, endpoint, body)
 	if err != nil {
 		return err
@@ -160,7 +229,7 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("API error: status %d, body: %s", resp.StatusCode, string(respBody))
+		return newAPIError(resp.StatusCode, respBody)
 	}
 
 	if result != nil {
@@ -169,6 +238,23 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string, body in
 		}
 	}
 
+	// İyzipay reports most failures with HTTP 200 and a non-success status
+	var base BaseResponse
+	if err := FlexibleUnmarshal(respBody, &base); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if base.Status != "success" {
+		return newAPIError(resp.StatusCode, respBody)
+	}
+
+	if c.config.VerifySignatures {
+		if signed, ok := result.(signedResponse); ok {
+			if err := signed.VerifySignature(c.config.SecretKey); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
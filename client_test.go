@@ -3,6 +3,7 @@ package iyzipay
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -251,4 +252,68 @@ func TestGetConfig(t *testing.T) {
 	if retrievedConfig.BaseURL != config.BaseURL {
 		t.Errorf("Expected base URL %s, got %s", config.BaseURL, retrievedConfig.BaseURL)
 	}
-}
\ No newline at end of file
+}
+
+func TestDoRequestAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantCode   string
+	}{
+		{
+			name:       "failure status with HTTP 200",
+			statusCode: http.StatusOK,
+			body:       `{"status":"failure","errorCode":"10051","errorMessage":"Kart limiti yetersiz","errorGroup":"NOT_SUFFICIENT_FUNDS","conversationId":"123"}`,
+			wantCode:   "10051",
+		},
+		{
+			name:       "HTTP error status",
+			statusCode: http.StatusUnauthorized,
+			body:       `{"status":"failure","errorCode":"1001","errorMessage":"api bilgileri bulunamadı"}`,
+			wantCode:   "1001",
+		},
+		{
+			name:       "HTTP error without body",
+			statusCode: http.StatusBadGateway,
+			body:       ``,
+			wantCode:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(&Config{
+				APIKey:      "test-api-key",
+				SecretKey:   "test-secret-key",
+				BaseURL:     server.URL,
+				RetryPolicy: &RetryPolicy{MaxAttempts: 1},
+			})
+
+			_, err := client.Payment.Retrieve(context.Background(), &RetrievePaymentRequest{PaymentID: "1"})
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError, got %v", err)
+			}
+
+			if apiErr.HTTPStatus != tt.statusCode {
+				t.Errorf("Expected HTTP status %d, got %d", tt.statusCode, apiErr.HTTPStatus)
+			}
+
+			if apiErr.ErrorCode != tt.wantCode {
+				t.Errorf("Expected error code %s, got %s", tt.wantCode, apiErr.ErrorCode)
+			}
+
+			if string(apiErr.Body) != tt.body {
+				t.Errorf("Expected raw body %s, got %s", tt.body, string(apiErr.Body))
+			}
+		})
+	}
+}
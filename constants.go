@@ -25,6 +25,19 @@ const (
 	PaymentGroupSubscription = "SUBSCRIPTION"
 )
 
+// Payment Phase constants
+const (
+	PaymentPhaseAuth     = "AUTH"
+	PaymentPhasePreAuth  = "PRE_AUTH"
+	PaymentPhasePostAuth = "POST_AUTH"
+)
+
+// Payment Status constants
+const (
+	PaymentStatusSuccess = "SUCCESS"
+	PaymentStatusFailure = "FAILURE"
+)
+
 // Basket Item Type constants
 const (
 	BasketItemTypePhysical = "PHYSICAL"
@@ -100,6 +113,21 @@ const (
 	SubscriptionInitialStatusPending = "PENDING"
 )
 
+// Webhook Event Types
+const (
+	WebhookEventAPIAuth                  = "API_AUTH"
+	WebhookEventThreeDSAuth              = "THREE_DS_AUTH"
+	WebhookEventThreeDSCallback          = "THREE_DS_CALLBACK"
+	WebhookEventCheckoutFormAuth         = "CHECKOUT_FORM_AUTH"
+	WebhookEventBKMAuth                  = "BKM_AUTH"
+	WebhookEventBalance                  = "BALANCE"
+	WebhookEventAPMAuth                  = "APM_AUTH"
+	WebhookEventRefund                   = "REFUND"
+	WebhookEventCancel                   = "CANCEL"
+	WebhookEventSubscriptionOrderSuccess = "subscription.order.success"
+	WebhookEventSubscriptionOrderFailure = "subscription.order.failure"
+)
+
 // HTTP Headers
 const (
 	HeaderRandomString                 = "x-iyzi-rnd"
@@ -108,6 +136,7 @@ const (
 	HeaderAuthorizationFallback        = "Authorization_Fallback"
 	HeaderIyziWSV1                     = "IYZWS"
 	HeaderIyziWSV2                     = "IYZWSv2"
+	HeaderWebhookSignatureV3           = "X-IYZ-SIGNATURE-V3"
 	ClientVersion                      = "iyzipay-go-1.0.0"
 	Separator                          = ":"
 	RandomStringSize                   = 8
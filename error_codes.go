@@ -0,0 +1,158 @@
+package iyzipay
+
+import (
+	"errors"
+	"net/http"
+)
+
+// ErrorCategory groups İyzipay error codes by how the caller should react
+type ErrorCategory string
+
+// Error Category constants
+const (
+	ErrorCategoryDecline    ErrorCategory = "decline"
+	ErrorCategoryFraud      ErrorCategory = "fraud"
+	ErrorCategoryValidation ErrorCategory = "validation"
+	ErrorCategoryAuth       ErrorCategory = "auth"
+	ErrorCategorySystem     ErrorCategory = "system"
+	ErrorCategoryUnknown    ErrorCategory = "unknown"
+)
+
+// Sentinel errors matched by APIError through errors.Is
+var (
+	ErrInsufficientFunds  = errors.New("iyzipay: insufficient funds")
+	ErrDoNotHonour        = errors.New("iyzipay: do not honour")
+	ErrInvalidTransaction = errors.New("iyzipay: invalid transaction")
+	ErrLostCard           = errors.New("iyzipay: lost card")
+	ErrStolenCard         = errors.New("iyzipay: stolen card")
+	ErrExpiredCard        = errors.New("iyzipay: expired card")
+	ErrInvalidExpiryDate  = errors.New("iyzipay: invalid expiry date")
+	ErrInvalidCVC         = errors.New("iyzipay: invalid cvc")
+	ErrInvalidCardNumber  = errors.New("iyzipay: invalid card number")
+	ErrFraudSuspected     = errors.New("iyzipay: fraud suspected")
+	ErrThreeDSRequired    = errors.New("iyzipay: 3D Secure required")
+	ErrBankTimeout        = errors.New("iyzipay: bank timeout")
+	ErrInvalidSignature   = errors.New("iyzipay: invalid signature")
+	ErrInvalidCredentials = errors.New("iyzipay: invalid api credentials")
+)
+
+// ErrorCodeInfo describes a known İyzipay error code
+type ErrorCodeInfo struct {
+	Code      string
+	Category  ErrorCategory
+	Retryable bool
+	MessageTR string
+	MessageEN string
+	Err       error
+}
+
+// Message returns the customer-facing message for the given locale
+func (i ErrorCodeInfo) Message(locale string) string {
+	if locale == LocaleEN {
+		return i.MessageEN
+	}
+	return i.MessageTR
+}
+
+// Generic customer-facing messages used for unknown codes and for codes whose
+// details should not be shown to the customer
+const (
+	genericMessageTR = "İşleminiz gerçekleştirilemedi, lütfen daha sonra tekrar deneyiniz"
+	genericMessageEN = "Your transaction could not be completed, please try again later"
+	declineMessageTR = "İşleminiz bankanız tarafından onaylanmadı, lütfen başka bir kart deneyiniz"
+	declineMessageEN = "Your transaction was declined by your bank, please try another card"
+)
+
+var errorCodeCatalog = map[string]ErrorCodeInfo{
+	"10051": {Category: ErrorCategoryDecline, Err: ErrInsufficientFunds, MessageTR: "Kart limitiniz yetersiz, lütfen başka bir kart deneyiniz", MessageEN: "Insufficient card limit or balance, please try another card"},
+	"10005": {Category: ErrorCategoryDecline, Err: ErrDoNotHonour, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10012": {Category: ErrorCategoryDecline, Err: ErrInvalidTransaction, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10054": {Category: ErrorCategoryDecline, Err: ErrExpiredCard, MessageTR: "Kartınızın süresi dolmuş, lütfen başka bir kart deneyiniz", MessageEN: "Your card has expired, please try another card"},
+	"10057": {Category: ErrorCategoryDecline, MessageTR: "Kart sahibi bu işlemi yapamaz", MessageEN: "The card holder is not permitted to perform this transaction"},
+	"10058": {Category: ErrorCategoryDecline, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10093": {Category: ErrorCategoryDecline, MessageTR: "Kartınız internetten alışverişe kapalıdır", MessageEN: "Your card is closed to online purchases"},
+	"10201": {Category: ErrorCategoryDecline, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10207": {Category: ErrorCategoryDecline, MessageTR: "Lütfen bankanızdan onay alınız", MessageEN: "Please get approval from your bank"},
+	"10209": {Category: ErrorCategoryDecline, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10222": {Category: ErrorCategoryDecline, MessageTR: "Kartınız taksitli işleme kapalıdır", MessageEN: "Your card is closed to installment transactions"},
+	"10225": {Category: ErrorCategoryDecline, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10226": {Category: ErrorCategoryDecline, MessageTR: "İzin verilen PIN giriş sayısı aşıldı", MessageEN: "The allowed number of PIN attempts was exceeded"},
+	"10227": {Category: ErrorCategoryDecline, MessageTR: "Geçersiz PIN", MessageEN: "Invalid PIN"},
+
+	"10034": {Category: ErrorCategoryFraud, Err: ErrFraudSuspected, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10041": {Category: ErrorCategoryFraud, Err: ErrLostCard, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+	"10043": {Category: ErrorCategoryFraud, Err: ErrStolenCard, MessageTR: declineMessageTR, MessageEN: declineMessageEN},
+
+	"11":    {Category: ErrorCategoryValidation, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+	"12":    {Category: ErrorCategoryValidation, Err: ErrInvalidCardNumber, MessageTR: "Kart numarası geçersiz", MessageEN: "Invalid card number"},
+	"17":    {Category: ErrorCategoryValidation, Err: ErrInvalidExpiryDate, MessageTR: "Son kullanma tarihi geçersiz", MessageEN: "Invalid expiry date"},
+	"10084": {Category: ErrorCategoryValidation, Err: ErrInvalidCVC, MessageTR: "CVC bilgisi hatalı", MessageEN: "Invalid security code (CVC)"},
+	"10206": {Category: ErrorCategoryValidation, Err: ErrInvalidCVC, MessageTR: "CVC uzunluğu geçersiz", MessageEN: "Invalid security code (CVC) length"},
+	"10213": {Category: ErrorCategoryValidation, Err: ErrInvalidCardNumber, MessageTR: "Kart numarası geçersiz", MessageEN: "Invalid card number"},
+	"10215": {Category: ErrorCategoryValidation, Err: ErrInvalidCardNumber, MessageTR: "Kart numarası geçersiz", MessageEN: "Invalid card number"},
+	"10216": {Category: ErrorCategoryValidation, MessageTR: "Kartınızın bankası bulunamadı", MessageEN: "The bank of your card could not be found"},
+	"10229": {Category: ErrorCategoryValidation, Err: ErrInvalidExpiryDate, MessageTR: "Son kullanma tarihi geçersiz", MessageEN: "Invalid expiry date"},
+	"10232": {Category: ErrorCategoryValidation, MessageTR: "Geçersiz tutar", MessageEN: "Invalid amount"},
+
+	"1000":  {Category: ErrorCategoryAuth, Err: ErrInvalidSignature, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+	"1001":  {Category: ErrorCategoryAuth, Err: ErrInvalidCredentials, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+	"10210": {Category: ErrorCategoryAuth, MessageTR: "3D Secure doğrulaması başarısız", MessageEN: "3D Secure authentication failed"},
+	"10211": {Category: ErrorCategoryAuth, MessageTR: "3D Secure doğrulaması başarısız", MessageEN: "3D Secure authentication failed"},
+	"10217": {Category: ErrorCategoryAuth, Err: ErrThreeDSRequired, MessageTR: "Kartınız yalnızca 3D Secure ile kullanılabilir", MessageEN: "Your card can only be used with 3D Secure"},
+
+	"10204": {Category: ErrorCategorySystem, Retryable: true, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+	"10214": {Category: ErrorCategorySystem, Retryable: true, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+	"10219": {Category: ErrorCategorySystem, Retryable: true, Err: ErrBankTimeout, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+	"10223": {Category: ErrorCategorySystem, Retryable: true, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+	"10228": {Category: ErrorCategorySystem, Retryable: true, MessageTR: genericMessageTR, MessageEN: genericMessageEN},
+}
+
+// LookupErrorCode returns the catalog entry for an İyzipay error code
+func LookupErrorCode(code string) (ErrorCodeInfo, bool) {
+	info, ok := errorCodeCatalog[code]
+	if !ok {
+		return ErrorCodeInfo{}, false
+	}
+	info.Code = code
+	return info, true
+}
+
+// info returns the catalog entry for the error, classifying unknown codes by HTTP status
+func (e *APIError) info() ErrorCodeInfo {
+	if info, ok := LookupErrorCode(e.ErrorCode); ok {
+		return info
+	}
+
+	info := ErrorCodeInfo{
+		Code:      e.ErrorCode,
+		Category:  ErrorCategoryUnknown,
+		MessageTR: genericMessageTR,
+		MessageEN: genericMessageEN,
+	}
+	if e.HTTPStatus >= http.StatusInternalServerError || e.HTTPStatus == http.StatusTooManyRequests {
+		info.Category = ErrorCategorySystem
+		info.Retryable = true
+	}
+	return info
+}
+
+// Category returns the category of the error code
+func (e *APIError) Category() ErrorCategory {
+	return e.info().Category
+}
+
+// Retryable reports whether the same request may succeed if retried later
+func (e *APIError) Retryable() bool {
+	return e.info().Retryable
+}
+
+// CustomerMessage returns a message that is safe to show to the customer in the given locale
+func (e *APIError) CustomerMessage(locale string) string {
+	return e.info().Message(locale)
+}
+
+// Is reports whether target is the sentinel error registered for the error code
+func (e *APIError) Is(target error) bool {
+	info, ok := LookupErrorCode(e.ErrorCode)
+	return ok && info.Err != nil && info.Err == target
+}
@@ -0,0 +1,42 @@
+package iyzipay
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorCatalog(t *testing.T) {
+	insufficientFunds := &APIError{HTTPStatus: http.StatusOK, ErrorCode: "10051"}
+	if !errors.Is(insufficientFunds, ErrInsufficientFunds) {
+		t.Error("Expected error to match ErrInsufficientFunds")
+	}
+
+	if errors.Is(insufficientFunds, ErrThreeDSRequired) {
+		t.Error("Expected error not to match ErrThreeDSRequired")
+	}
+
+	if insufficientFunds.Category() != ErrorCategoryDecline || insufficientFunds.Retryable() {
+		t.Errorf("Unexpected classification %s retryable=%t", insufficientFunds.Category(), insufficientFunds.Retryable())
+	}
+
+	if insufficientFunds.CustomerMessage(LocaleEN) == insufficientFunds.CustomerMessage(LocaleTR) {
+		t.Error("Expected localized customer messages")
+	}
+
+	wrapped := fmt.Errorf("checkout: %w", &APIError{ErrorCode: "10217"})
+	if !errors.Is(wrapped, ErrThreeDSRequired) {
+		t.Error("Expected wrapped error to match ErrThreeDSRequired")
+	}
+
+	invalidExpiry := &APIError{HTTPStatus: http.StatusOK, ErrorCode: "10229"}
+	if !errors.Is(invalidExpiry, ErrInvalidExpiryDate) || errors.Is(invalidExpiry, ErrExpiredCard) {
+		t.Error("Expected invalid expiry date not to match ErrExpiredCard")
+	}
+
+	unknown := &APIError{HTTPStatus: http.StatusServiceUnavailable, ErrorCode: "99999"}
+	if unknown.Category() != ErrorCategorySystem || !unknown.Retryable() {
+		t.Errorf("Expected unknown 5xx error to be retryable system error, got %s", unknown.Category())
+	}
+}
@@ -0,0 +1,39 @@
+package iyzipay
+
+import "fmt"
+
+// APIError represents an error returned by the İyzipay API, either as an
+// HTTP error status or as a response whose status is not "success"
+type APIError struct {
+	HTTPStatus     int
+	Status         string
+	ErrorCode      string
+	ErrorMessage   string
+	ErrorGroup     string
+	ConversationID string
+	Body           []byte
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.ErrorCode == "" && e.ErrorMessage == "" {
+		return fmt.Sprintf("iyzipay: API error: status %d, body: %s", e.HTTPStatus, string(e.Body))
+	}
+	return fmt.Sprintf("iyzipay: API error %s: %s (status %d)", e.ErrorCode, e.ErrorMessage, e.HTTPStatus)
+}
+
+// newAPIError builds an APIError from the HTTP status and raw response body
+func newAPIError(httpStatus int, body []byte) *APIError {
+	var base BaseResponse
+	FlexibleUnmarshal(body, &base)
+
+	return &APIError{
+		HTTPStatus:     httpStatus,
+		Status:         base.Status,
+		ErrorCode:      base.ErrorCode,
+		ErrorMessage:   base.ErrorMessage,
+		ErrorGroup:     base.ErrorGroup,
+		ConversationID: base.ConversationID,
+		Body:           body,
+	}
+}
@@ -121,21 +121,10 @@ func main() {
 		fmt.Printf("Auth Code: %s\n", response.AuthCode)
 
 		// İmza doğrulama
-		if response.Signature != "" {
-			params := []string{
-				response.PaymentID,
-				response.Currency,
-				response.BasketID,
-				response.ConversationID,
-				response.PaidPrice,
-				response.Price,
-			}
-			expectedSignature := iyzipay.CalculateHMACSignature(params, client.GetConfig().SecretKey)
-			if response.Signature == expectedSignature {
-				fmt.Println("✓ Signature verified successfully")
-			} else {
-				fmt.Println("✗ Signature verification failed")
-			}
+		if err := response.VerifySignature(client.GetConfig().SecretKey); err == nil {
+			fmt.Println("✓ Signature verified successfully")
+		} else {
+			fmt.Println("✗ Signature verification failed")
 		}
 
 		// Item transactions detayları
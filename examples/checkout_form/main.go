@@ -102,17 +102,10 @@ func main() {
 		fmt.Printf("Token Expire Time: %d\n", initResponse.TokenExpireTime)
 
 		// İmza doğrulama
-		if initResponse.Signature != "" {
-			params := []string{
-				initResponse.ConversationID,
-				initResponse.Token,
-			}
-			expectedSignature := iyzipay.CalculateHMACSignature(params, client.GetConfig().SecretKey)
-			if initResponse.Signature == expectedSignature {
-				fmt.Println("✓ Checkout Form Initialize Signature verified successfully")
-			} else {
-				fmt.Println("✗ Checkout Form Initialize Signature verification failed")
-			}
+		if err := initResponse.VerifySignature(client.GetConfig().SecretKey); err == nil {
+			fmt.Println("✓ Checkout Form Initialize Signature verified successfully")
+		} else {
+			fmt.Println("✗ Checkout Form Initialize Signature verification failed")
 		}
 
 		// Checkout form HTML içeriği konsola yazdırılır
@@ -154,21 +147,10 @@ func main() {
 				fmt.Printf("MD Status: %d\n", resultResponse.MdStatus)
 
 				// İmza doğrulama
-				if resultResponse.Signature != "" {
-					params := []string{
-						resultResponse.PaymentID,
-						resultResponse.Currency,
-						resultResponse.BasketID,
-						resultResponse.ConversationID,
-						resultResponse.PaidPrice,
-						resultResponse.Price,
-					}
-					expectedSignature := iyzipay.CalculateHMACSignature(params, client.GetConfig().SecretKey)
-					if resultResponse.Signature == expectedSignature {
-						fmt.Println("✓ Checkout Form Result Signature verified successfully")
-					} else {
-						fmt.Println("✗ Checkout Form Result Signature verification failed")
-					}
+				if err := resultResponse.VerifySignature(client.GetConfig().SecretKey); err == nil {
+					fmt.Println("✓ Checkout Form Result Signature verified successfully")
+				} else {
+					fmt.Println("✗ Checkout Form Result Signature verification failed")
 				}
 
 				// Item transactions detayları
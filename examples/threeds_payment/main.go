@@ -107,17 +107,10 @@ func main() {
 		fmt.Printf("MD Status: %d\n", initResponse.MdStatus)
 
 		// İmza doğrulama
-		if initResponse.Signature != "" {
-			params := []string{
-				initResponse.PaymentID,
-				initResponse.ConversationID,
-			}
-			expectedSignature := iyzipay.CalculateHMACSignature(params, client.GetConfig().SecretKey)
-			if initResponse.Signature == expectedSignature {
-				fmt.Println("✓ 3DS Initialize Signature verified successfully")
-			} else {
-				fmt.Println("✗ 3DS Initialize Signature verification failed")
-			}
+		if err := initResponse.VerifySignature(client.GetConfig().SecretKey); err == nil {
+			fmt.Println("✓ 3DS Initialize Signature verified successfully")
+		} else {
+			fmt.Println("✗ 3DS Initialize Signature verification failed")
 		}
 
 		// 3D Secure form HTML içeriği konsola yazdırılır
@@ -151,21 +144,10 @@ func main() {
 				fmt.Printf("Auth Code: %s\n", paymentResponse.AuthCode)
 
 				// İmza doğrulama
-				if paymentResponse.Signature != "" {
-					params := []string{
-						paymentResponse.PaymentID,
-						paymentResponse.Currency,
-						paymentResponse.BasketID,
-						paymentResponse.ConversationID,
-						paymentResponse.PaidPrice,
-						paymentResponse.Price,
-					}
-					expectedSignature := iyzipay.CalculateHMACSignature(params, client.GetConfig().SecretKey)
-					if paymentResponse.Signature == expectedSignature {
-						fmt.Println("✓ 3DS Payment Signature verified successfully")
-					} else {
-						fmt.Println("✗ 3DS Payment Signature verification failed")
-					}
+				if err := paymentResponse.VerifySignature(client.GetConfig().SecretKey); err == nil {
+					fmt.Println("✓ 3DS Payment Signature verified successfully")
+				} else {
+					fmt.Println("✗ 3DS Payment Signature verification failed")
 				}
 			} else {
 				fmt.Printf("3DS Payment Failed - Error: %s\n", paymentResponse.ErrorMessage)
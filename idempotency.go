@@ -0,0 +1,326 @@
+package iyzipay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrIdempotencyInProgress is returned when a request with the same idempotency key
+// is still in flight, or an earlier attempt ended ambiguously and could not be resolved yet
+var ErrIdempotencyInProgress = errors.New("iyzipay: request with the same idempotency key is in progress")
+
+// idempotencyPendingTimeout is how long a pending record is considered in flight
+// before it is treated as an abandoned attempt and reconciled
+const idempotencyPendingTimeout = 2 * time.Minute
+
+// errorCodePaymentNotFound is returned by Payment.Retrieve when no payment matches the request
+const errorCodePaymentNotFound = "5"
+
+// IdempotencyStatus represents the state of an idempotency record
+type IdempotencyStatus string
+
+// Idempotency Status constants
+const (
+	IdempotencyStatusPending   IdempotencyStatus = "PENDING"
+	IdempotencyStatusCompleted IdempotencyStatus = "COMPLETED"
+)
+
+// IdempotencyRecord represents a stored mutating request and its result
+type IdempotencyRecord struct {
+	Key       string
+	Status    IdempotencyStatus
+	Response  []byte
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IdempotencyStore persists idempotency records. Implementations must be safe for concurrent use.
+type IdempotencyStore interface {
+	// Begin atomically creates a pending record for key. If a record already
+	// exists it is returned unchanged and created is false.
+	Begin(ctx context.Context, key string) (record *IdempotencyRecord, created bool, err error)
+	// Complete marks the record for key as completed with the JSON encoded response
+	Complete(ctx context.Context, key string, response []byte) error
+	// Delete removes the record for key so that the request can be sent again
+	Delete(ctx context.Context, key string) error
+	// Reclaim atomically replaces an abandoned pending record for key with a fresh pending
+	// record, but only if its UpdatedAt still equals updatedAt. It reports whether the
+	// record was claimed, so that only one caller takes over an abandoned request.
+	Reclaim(ctx context.Context, key string, updatedAt time.Time) (claimed bool, err error)
+}
+
+// MemoryIdempotencyStore is an in-memory IdempotencyStore
+type MemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	records map[string]*IdempotencyRecord
+}
+
+// NewMemoryIdempotencyStore creates an in-memory store. Records older than ttl are
+// discarded; a ttl of zero keeps records for the lifetime of the process.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		ttl:     ttl,
+		records: make(map[string]*IdempotencyRecord),
+	}
+}
+
+// Begin implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Begin(ctx context.Context, key string) (*IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	if record, ok := s.records[key]; ok {
+		copied := *record
+		return &copied, false, nil
+	}
+
+	record := &IdempotencyRecord{
+		Key:       key,
+		Status:    IdempotencyStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.records[key] = record
+
+	copied := *record
+	return &copied, true, nil
+}
+
+// Complete implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Complete(ctx context.Context, key string, response []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+	record, ok := s.records[key]
+	if !ok {
+		record = &IdempotencyRecord{Key: key, CreatedAt: now}
+		s.records[key] = record
+	}
+	record.Status = IdempotencyStatusCompleted
+	record.Response = response
+	record.UpdatedAt = now
+	return nil
+}
+
+// sweep removes the records that are older than ttl. The caller must hold s.mu.
+func (s *MemoryIdempotencyStore) sweep(now time.Time) {
+	if s.ttl <= 0 {
+		return
+	}
+	for key, record := range s.records {
+		if now.Sub(record.CreatedAt) >= s.ttl {
+			delete(s.records, key)
+		}
+	}
+}
+
+// Delete implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// Reclaim implements IdempotencyStore
+func (s *MemoryIdempotencyStore) Reclaim(ctx context.Context, key string, updatedAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok || record.Status != IdempotencyStatusPending || !record.UpdatedAt.Equal(updatedAt) {
+		return false, nil
+	}
+
+	now := time.Now()
+	s.records[key] = &IdempotencyRecord{
+		Key:       key,
+		Status:    IdempotencyStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	return true, nil
+}
+
+// idempotencyKey builds the key for a mutating operation. It returns an empty
+// key, which disables the idempotency layer, when conversationID is empty.
+func idempotencyKey(operation, conversationID string, parts ...string) string {
+	if conversationID == "" {
+		return ""
+	}
+	key := operation + ":" + conversationID
+	for _, part := range parts {
+		if part != "" {
+			key += ":" + part
+		}
+	}
+	return key
+}
+
+// reconcileOutcome is what a lookup found out about an ambiguous attempt
+type reconcileOutcome int
+
+const (
+	reconcileUnknown reconcileOutcome = iota
+	reconcileApplied
+	reconcileNotApplied
+)
+
+// reconcileFunc looks up the outcome of an earlier attempt. When the attempt was applied
+// its result is decoded into result; when the outcome is unknown an error explains why.
+type reconcileFunc func(ctx context.Context, result interface{}) (reconcileOutcome, error)
+
+// reconcileByPayment returns a reconcileFunc that looks up the payment with Payment.Retrieve.
+// Only a payment with the SUCCESS status counts as applied, and only a FAILURE payment or
+// an explicit "payment not found" error counts as not applied.
+func (c *Client) reconcileByPayment(request *RetrievePaymentRequest) reconcileFunc {
+	return func(ctx context.Context, result interface{}) (reconcileOutcome, error) {
+		var payment PaymentResponse
+		err := c.doRequest(ctx, "Payment.Retrieve", http.MethodPost, EndpointPaymentDetail, request, &payment)
+		if err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.ErrorCode == errorCodePaymentNotFound {
+				return reconcileNotApplied, nil
+			}
+			// Rate limits, auth errors and outages say nothing about the payment
+			return reconcileUnknown, err
+		}
+
+		switch payment.PaymentStatus {
+		case PaymentStatusSuccess:
+			if response, ok := result.(*PaymentResponse); ok {
+				*response = payment
+			}
+			return reconcileApplied, nil
+		case PaymentStatusFailure:
+			return reconcileNotApplied, nil
+		default:
+			return reconcileUnknown, fmt.Errorf("payment status %q", payment.PaymentStatus)
+		}
+	}
+}
+
+// isAmbiguousError reports whether the request may have taken effect despite err
+func isAmbiguousError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatus >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// doIdempotentRequest performs the service call named operation at most once per key when an
+// IdempotencyStore is configured. Completed results are replayed from the store.
+// After an ambiguous failure the outcome is looked up with reconcile, if given.
+func (c *Client) doIdempotentRequest(ctx context.Context, operation, key, method, endpoint string, body, result interface{}, reconcile reconcileFunc) error {
+	store := c.config.IdempotencyStore
+	if store == nil || key == "" {
+		return c.doRequest(ctx, operation, method, endpoint, body, result)
+	}
+
+	op := &Operation{Name: operation, Method: method, Endpoint: endpoint}
+	return c.intercept(ctx, op, body, result, func(ctx context.Context) error {
+		return c.sendIdempotent(ctx, store, key, method, endpoint, body, result, reconcile)
+	})
+}
+
+// sendIdempotent sends the request unless the store already has an outcome for key
+func (c *Client) sendIdempotent(ctx context.Context, store IdempotencyStore, key, method, endpoint string, body, result interface{}, reconcile reconcileFunc) error {
+	record, created, err := store.Begin(ctx, key)
+	if err != nil {
+		return fmt.Errorf("idempotency store: %w", err)
+	}
+
+	if !created {
+		if record.Status == IdempotencyStatusCompleted {
+			return json.Unmarshal(record.Response, result)
+		}
+		if time.Since(record.UpdatedAt) < idempotencyPendingTimeout {
+			return ErrIdempotencyInProgress
+		}
+
+		// An earlier attempt was abandoned; find out whether it took effect. Operations
+		// that cannot be looked up are sent again once the pending record has expired.
+		if reconcile != nil {
+			resolved, err := c.resolve(ctx, store, key, result, reconcile)
+			if err != nil || resolved {
+				return err
+			}
+		}
+
+		// It had no effect; send it again unless another caller took over first
+		claimed, err := store.Reclaim(ctx, key, record.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("idempotency store: %w", err)
+		}
+		if !claimed {
+			return ErrIdempotencyInProgress
+		}
+	}
+
+	requestErr := c.send(ctx, method, endpoint, body, result)
+	if requestErr == nil {
+		return c.complete(ctx, store, key, result)
+	}
+
+	if !isAmbiguousError(requestErr) {
+		// The API rejected the request, so it is safe to send it again
+		if err := store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("idempotency store: %w", err)
+		}
+		return requestErr
+	}
+
+	resolved, err := c.resolve(context.WithoutCancel(ctx), store, key, result, reconcile)
+	if resolved {
+		return err
+	}
+	if err == nil {
+		// The earlier attempt had no effect
+		if err := store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("idempotency store: %w", err)
+		}
+	}
+	return requestErr
+}
+
+// resolve looks up the outcome of an ambiguous attempt. It reports resolved=true when
+// the attempt took effect, and returns an error when the outcome is still unknown.
+func (c *Client) resolve(ctx context.Context, store IdempotencyStore, key string, result interface{}, reconcile reconcileFunc) (bool, error) {
+	if reconcile == nil {
+		return false, ErrIdempotencyInProgress
+	}
+
+	outcome, err := reconcile(ctx, result)
+	switch outcome {
+	case reconcileApplied:
+		return true, c.complete(ctx, store, key, result)
+	case reconcileNotApplied:
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %v", ErrIdempotencyInProgress, err)
+	}
+}
+
+// complete stores the successful result for key
+func (c *Client) complete(ctx context.Context, store IdempotencyStore, key string, result interface{}) error {
+	response, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("idempotency store: %w", err)
+	}
+	if err := store.Complete(ctx, key, response); err != nil {
+		return fmt.Errorf("idempotency store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package iyzipay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIdempotentPaymentReplay(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status": "success", "conversationId": "123", "paymentId": "12345"}`))
+	})
+	client.config.IdempotencyStore = NewMemoryIdempotencyStore(time.Hour)
+
+	request := &PaymentRequest{ConversationID: "123", BasketID: "B1"}
+	first, err := client.Payment.Create(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Payment create failed: %v", err)
+	}
+
+	second, err := client.Payment.Create(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Payment replay failed: %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected 1 request, got %d", calls)
+	}
+
+	if second.PaymentID != first.PaymentID {
+		t.Errorf("Expected replayed payment ID %s, got %s", first.PaymentID, second.PaymentID)
+	}
+}
+
+func TestIdempotentPaymentReconcile(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == EndpointPaymentAuth {
+			w.WriteHeader(http.StatusGatewayTimeout)
+			return
+		}
+		w.Write([]byte(`{"status": "success", "conversationId": "123", "paymentId": "12345", "paymentStatus": "SUCCESS"}`))
+	})
+	client.config.IdempotencyStore = NewMemoryIdempotencyStore(time.Hour)
+
+	request := &PaymentRequest{ConversationID: "123", BasketID: "B1"}
+	result, err := client.Payment.Create(context.Background(), request)
+	if err != nil {
+		t.Fatalf("Expected payment to be reconciled, got %v", err)
+	}
+
+	if result.PaymentID != "12345" {
+		t.Errorf("Expected payment ID 12345, got %s", result.PaymentID)
+	}
+
+	if len(paths) != 2 || paths[1] != EndpointPaymentDetail {
+		t.Errorf("Expected create followed by detail lookup, got %v", paths)
+	}
+
+	if _, err := client.Payment.Create(context.Background(), request); err != nil {
+		t.Fatalf("Payment replay failed: %v", err)
+	}
+
+	if len(paths) != 2 {
+		t.Errorf("Expected reconciled payment to be replayed, got %d requests", len(paths))
+	}
+}
+
+func TestIdempotentPaymentDefinitiveFailure(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status": "failure", "errorCode": "10051", "conversationId": "123"}`))
+	})
+	client.config.IdempotencyStore = NewMemoryIdempotencyStore(time.Hour)
+
+	request := &PaymentRequest{ConversationID: "123"}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Payment.Create(context.Background(), request); !errors.Is(err, ErrInsufficientFunds) {
+			t.Fatalf("Expected ErrInsufficientFunds, got %v", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected declined payment to be sent again, got %d requests", calls)
+	}
+}
+
+func TestIdempotentRequestInProgress(t *testing.T) {
+	store := NewMemoryIdempotencyStore(0)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request to be sent")
+	})
+	client.config.IdempotencyStore = store
+
+	if _, _, err := store.Begin(context.Background(), idempotencyKey("Refund.Create", "123", "T1")); err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	_, err := client.Refund.Create(context.Background(), &RefundRequest{ConversationID: "123", PaymentTransactionID: "T1"})
+	if !errors.Is(err, ErrIdempotencyInProgress) {
+		t.Errorf("Expected ErrIdempotencyInProgress, got %v", err)
+	}
+}
+
+func TestIdempotentReconcilePaymentStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		paymentStatus string
+		wantErr       bool
+		wantResend    bool
+	}{
+		{name: "success", paymentStatus: PaymentStatusSuccess},
+		{name: "failure", paymentStatus: PaymentStatusFailure, wantErr: true, wantResend: true},
+		{name: "still in 3DS", paymentStatus: "INIT_THREEDS", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authCalls int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == EndpointPayment3DSecureAuth {
+					authCalls++
+					w.WriteHeader(http.StatusGatewayTimeout)
+					return
+				}
+				fmt.Fprintf(w, `{"status": "success", "conversationId": "123", "paymentId": "12345", "paymentStatus": %q}`, tt.paymentStatus)
+			})
+			client.config.IdempotencyStore = NewMemoryIdempotencyStore(time.Hour)
+
+			request := &ThreedsPaymentRequest{ConversationID: "123", PaymentID: "12345"}
+			_, err := client.ThreedsPayment.Create(context.Background(), request)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error=%t, got %v", tt.wantErr, err)
+			}
+
+			_, err = client.ThreedsPayment.Create(context.Background(), request)
+			if tt.paymentStatus == "INIT_THREEDS" && !errors.Is(err, ErrIdempotencyInProgress) {
+				t.Errorf("Expected unresolved payment to stay in progress, got %v", err)
+			}
+
+			wantCalls := 1
+			if tt.wantResend {
+				wantCalls = 2
+			}
+			if authCalls != wantCalls {
+				t.Errorf("Expected %d auth requests, got %d", wantCalls, authCalls)
+			}
+		})
+	}
+}
+
+func TestIdempotentReconcileLookupFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests},
+		{name: "failure status", status: http.StatusOK, body: `{"status": "failure", "errorCode": "1001", "errorMessage": "api bilgileri bulunamadı"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authCalls int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == EndpointPaymentAuth {
+					authCalls++
+					w.WriteHeader(http.StatusGatewayTimeout)
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			client.config.IdempotencyStore = NewMemoryIdempotencyStore(time.Hour)
+			client.config.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+
+			request := &PaymentRequest{ConversationID: "123", BasketID: "B1"}
+			if _, err := client.Payment.Create(context.Background(), request); err == nil {
+				t.Fatal("Expected error for unresolved payment")
+			}
+
+			if _, err := client.Payment.Create(context.Background(), request); !errors.Is(err, ErrIdempotencyInProgress) {
+				t.Errorf("Expected ErrIdempotencyInProgress, got %v", err)
+			}
+
+			if authCalls != 1 {
+				t.Errorf("Expected 1 auth request, got %d", authCalls)
+			}
+		})
+	}
+}
+
+func TestIdempotentAbandonedRequestTakeover(t *testing.T) {
+	var authCalls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == EndpointPaymentAuth {
+			authCalls++
+			w.Write([]byte(`{"status": "success", "conversationId": "123", "paymentId": "12345"}`))
+			return
+		}
+		w.Write([]byte(`{"status": "failure", "errorCode": "5", "errorMessage": "payment not found"}`))
+	})
+	store := NewMemoryIdempotencyStore(time.Hour)
+	client.config.IdempotencyStore = store
+
+	key := idempotencyKey("Payment.Create", "123", "B1")
+	stale := time.Now().Add(-2 * idempotencyPendingTimeout)
+	store.records[key] = &IdempotencyRecord{Key: key, Status: IdempotencyStatusPending, CreatedAt: stale, UpdatedAt: stale}
+
+	if _, err := client.Payment.Create(context.Background(), &PaymentRequest{ConversationID: "123", BasketID: "B1"}); err != nil {
+		t.Fatalf("Expected abandoned payment to be sent again, got %v", err)
+	}
+
+	if authCalls != 1 {
+		t.Errorf("Expected 1 auth request, got %d", authCalls)
+	}
+}
+
+func TestMemoryIdempotencyStoreReclaim(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryIdempotencyStore(0)
+
+	record, _, err := store.Begin(ctx, "key")
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+
+	first, _ := store.Reclaim(ctx, "key", record.UpdatedAt)
+	second, _ := store.Reclaim(ctx, "key", record.UpdatedAt)
+	if !first || second {
+		t.Errorf("Expected only the first reclaim to succeed, got %t and %t", first, second)
+	}
+}
+
+func TestIdempotentAbandonedRefundExpires(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"status": "success", "conversationId": "123", "paymentTransactionId": "T1"}`))
+	})
+	store := NewMemoryIdempotencyStore(0)
+	client.config.IdempotencyStore = store
+
+	key := idempotencyKey("Refund.Create", "123", "T1")
+	stale := time.Now().Add(-2 * idempotencyPendingTimeout)
+	store.records[key] = &IdempotencyRecord{Key: key, Status: IdempotencyStatusPending, CreatedAt: stale, UpdatedAt: stale}
+
+	if _, err := client.Refund.Create(context.Background(), &RefundRequest{ConversationID: "123", PaymentTransactionID: "T1"}); err != nil {
+		t.Fatalf("Expected abandoned refund to be sent again, got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected 1 request, got %d", calls)
+	}
+}
+
+func TestMemoryIdempotencyStoreSweep(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryIdempotencyStore(time.Hour)
+
+	expired := time.Now().Add(-2 * time.Hour)
+	store.records["old"] = &IdempotencyRecord{Key: "old", Status: IdempotencyStatusCompleted, CreatedAt: expired, UpdatedAt: expired}
+
+	if err := store.Complete(ctx, "new", []byte(`{}`)); err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+
+	if _, ok := store.records["old"]; ok {
+		t.Error("Expected expired record to be removed")
+	}
+
+	if len(store.records) != 1 {
+		t.Errorf("Expected 1 record, got %d", len(store.records))
+	}
+}
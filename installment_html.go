@@ -0,0 +1,68 @@
+package iyzipay
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+)
+
+// InstallmentTableOptions configures the markup produced by RenderInstallmentTable
+type InstallmentTableOptions struct {
+	TableClass      string
+	CaptionClass    string
+	HeaderClass     string
+	RowClass        string
+	InstallmentText string
+	MonthlyText     string
+	TotalText       string
+	SingleText      string
+}
+
+// DefaultInstallmentTableOptions returns table options with Turkish labels and iyzipay- prefixed CSS classes
+func DefaultInstallmentTableOptions() *InstallmentTableOptions {
+	return &InstallmentTableOptions{
+		TableClass:      "iyzipay-installment-table",
+		CaptionClass:    "iyzipay-installment-caption",
+		HeaderClass:     "iyzipay-installment-header",
+		RowClass:        "iyzipay-installment-row",
+		InstallmentText: "Taksit Sayısı",
+		MonthlyText:     "Taksit Tutarı",
+		TotalText:       "Toplam Tutar",
+		SingleText:      "Tek Çekim",
+	}
+}
+
+var installmentTableTemplate = template.Must(template.New("installment").Parse(`{{- $options := .Options -}}
+{{- range .Details }}
+<table class="{{ $options.TableClass }}">
+<caption class="{{ $options.CaptionClass }}">{{ .BankName }} {{ .CardFamilyName }}</caption>
+<thead>
+<tr class="{{ $options.HeaderClass }}"><th>{{ $options.InstallmentText }}</th><th>{{ $options.MonthlyText }}</th><th>{{ $options.TotalText }}</th></tr>
+</thead>
+<tbody>
+{{- range .InstallmentPrices }}
+<tr class="{{ $options.RowClass }}" data-installment="{{ .InstallmentNumber }}"><td>{{ if eq .InstallmentNumber 1 }}{{ $options.SingleText }}{{ else }}{{ .InstallmentNumber }}{{ end }}</td><td>{{ .InstallmentPrice }}</td><td>{{ .TotalPrice }}</td></tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}
+`))
+
+// RenderInstallmentTable writes an HTML installment table for every card family in response.
+// A nil options value uses DefaultInstallmentTableOptions.
+func RenderInstallmentTable(w io.Writer, response *InstallmentInfoResponse, options *InstallmentTableOptions) error {
+	if response == nil {
+		return fmt.Errorf("installment info response cannot be nil")
+	}
+	if options == nil {
+		options = DefaultInstallmentTableOptions()
+	}
+
+	return installmentTableTemplate.Execute(w, struct {
+		Options *InstallmentTableOptions
+		Details []InstallmentDetail
+	}{
+		Options: options,
+		Details: response.InstallmentDetails,
+	})
+}
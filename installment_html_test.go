@@ -0,0 +1,45 @@
+package iyzipay
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRenderInstallmentTable(t *testing.T) {
+	response := &InstallmentInfoResponse{
+		InstallmentDetails: []InstallmentDetail{
+			{
+				BankName:       "Halkbank",
+				CardFamilyName: "Paraf",
+				InstallmentPrices: []InstallmentPrice{
+					{InstallmentNumber: 1, InstallmentPrice: "100.0", TotalPrice: "100.0"},
+					{InstallmentNumber: 3, InstallmentPrice: "34.5", TotalPrice: "103.5"},
+				},
+			},
+		},
+	}
+
+	options := DefaultInstallmentTableOptions()
+	options.TableClass = "custom-table"
+	options.SingleText = "<b>Single</b>"
+
+	var buf bytes.Buffer
+	if err := RenderInstallmentTable(&buf, response, options); err != nil {
+		t.Fatalf("RenderInstallmentTable() error = %v", err)
+	}
+
+	html := buf.String()
+	expectedParts := []string{
+		`<table class="custom-table">`,
+		"Halkbank Paraf",
+		`data-installment="3"`,
+		"<td>34.5</td><td>103.5</td>",
+		"&lt;b&gt;Single&lt;/b&gt;",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(html, part) {
+			t.Errorf("Expected output to contain %q, got %s", part, html)
+		}
+	}
+}
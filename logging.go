@@ -0,0 +1,233 @@
+package iyzipay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Redaction modes for the redact struct tag
+const (
+	// RedactPAN keeps the BIN and the last four digits of a card number
+	RedactPAN = "pan"
+	// RedactMask keeps only the last two characters
+	RedactMask = "mask"
+	// RedactOmit removes the field entirely
+	RedactOmit = "omit"
+)
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// sensitiveFields are the redaction modes applied by field name, case-insensitively, when a
+// field has no redact tag, so that a newly added card or identity field is never logged in clear
+var sensitiveFields = map[string]string{
+	"cardnumber":        RedactPAN,
+	"cvc":               RedactOmit,
+	"expiremonth":       RedactOmit,
+	"expireyear":        RedactOmit,
+	"identitynumber":    RedactMask,
+	"gsmnumber":         RedactMask,
+	"contactgsmnumber":  RedactMask,
+	"customergsmnumber": RedactMask,
+	"iban":              RedactMask,
+	"taxnumber":         RedactMask,
+}
+
+// Redact returns a copy of v that is safe to log. Structs become maps keyed by their
+// JSON names, and fields tagged with redact:"pan", redact:"mask" or redact:"omit" are
+// masked or removed. Untagged fields with a known sensitive name, such as cardNumber
+// or identityNumber, are redacted as if they were tagged.
+func Redact(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return redactValue(reflect.ValueOf(v))
+}
+
+func redactValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return redactValue(v.Elem())
+	case reflect.Struct:
+		if v.Type().Implements(jsonMarshalerType) || v.Type() == reflect.TypeOf(time.Time{}) {
+			return v.Interface()
+		}
+		fields := make(map[string]interface{})
+		redactStruct(v, fields)
+		return fields
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = redactValue(v.Index(i))
+		}
+		return items
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		entries := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries[formatMapKey(iter.Key())] = redactValue(iter.Value())
+		}
+		return entries
+	default:
+		return v.Interface()
+	}
+}
+
+// redactStruct adds the redacted fields of v to fields, flattening embedded structs like encoding/json
+func redactStruct(v reflect.Value, fields map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		value := v.Field(i)
+		if field.Anonymous && name == "" && value.Kind() == reflect.Struct {
+			redactStruct(value, fields)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "omitempty") && value.IsZero() {
+			continue
+		}
+
+		mode := field.Tag.Get("redact")
+		if mode == "" {
+			mode = sensitiveFields[strings.ToLower(name)]
+		}
+
+		switch mode {
+		case RedactOmit:
+			continue
+		case RedactPAN:
+			fields[name] = maskPAN(value.String())
+		case RedactMask:
+			fields[name] = maskValue(value.String())
+		default:
+			fields[name] = redactValue(value)
+		}
+	}
+}
+
+func formatMapKey(key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return key.String()
+	}
+	data, _ := json.Marshal(key.Interface())
+	return strings.Trim(string(data), `"`)
+}
+
+// maskPAN masks a card number down to its BIN and last four digits
+func maskPAN(pan string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, pan)
+	if len(digits) < 12 {
+		return strings.Repeat("*", len(digits))
+	}
+	return digits[:6] + strings.Repeat("*", len(digits)-10) + digits[len(digits)-4:]
+}
+
+// maskValue masks all but the last two characters of value
+func maskValue(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 4 {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-2) + string(runes[len(runes)-2:])
+}
+
+// logCall logs a completed service call. Redacted request and response bodies are
+// included when the logger is enabled for debug level.
+func (c *Client) logCall(ctx context.Context, op *Operation, request, response interface{}, latency time.Duration, err error) {
+	logger := c.config.Logger
+	if logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", op.Name),
+		slog.String("method", op.Method),
+		slog.String("endpoint", stripQuery(op.Endpoint)),
+		slog.Duration("latency", latency),
+	}
+	if conversationID := stringField(request, "ConversationID"); conversationID != "" {
+		attrs = append(attrs, slog.String("conversation_id", conversationID))
+	} else if conversationID := stringField(response, "ConversationID"); conversationID != "" {
+		attrs = append(attrs, slog.String("conversation_id", conversationID))
+	}
+	if status := stringField(response, "Status"); status != "" {
+		attrs = append(attrs, slog.String("status", status))
+	}
+
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelError
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			attrs = append(attrs,
+				slog.Int("http_status", apiErr.HTTPStatus),
+				slog.String("error_code", apiErr.ErrorCode),
+			)
+		}
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if logger.Enabled(ctx, slog.LevelDebug) {
+		attrs = append(attrs,
+			slog.Any("request", Redact(request)),
+			slog.Any("response", Redact(response)),
+		)
+	}
+
+	logger.LogAttrs(ctx, level, "iyzipay request", attrs...)
+}
+
+// stringField returns the named string field of the struct v points to, if any
+func stringField(v interface{}, name string) string {
+	if v == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	field := rv.FieldByName(name)
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+	return field.String()
+}
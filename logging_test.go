@@ -0,0 +1,139 @@
+package iyzipay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	request := &PaymentRequest{
+		ConversationID: "123",
+		PaymentCard: &PaymentCard{
+			CardHolderName: "John Doe",
+			CardNumber:     "5528790000000008",
+			ExpireMonth:    "12",
+			ExpireYear:     "2030",
+			CVC:            "123",
+		},
+		Buyer: &Buyer{
+			IdentityNumber: "74300864791",
+			GsmNumber:      "+905350000000",
+		},
+	}
+
+	redacted, ok := Redact(request).(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected map, got %T", Redact(request))
+	}
+
+	card := redacted["paymentCard"].(map[string]interface{})
+	if card["cardNumber"] != "552879******0008" {
+		t.Errorf("Expected masked card number, got %v", card["cardNumber"])
+	}
+
+	for _, field := range []string{"cvc", "expireMonth", "expireYear"} {
+		if _, ok := card[field]; ok {
+			t.Errorf("Expected %s to be removed", field)
+		}
+	}
+
+	buyer := redacted["buyer"].(map[string]interface{})
+	if buyer["identityNumber"] != "*********91" {
+		t.Errorf("Expected masked identity number, got %v", buyer["identityNumber"])
+	}
+
+	if buyer["gsmNumber"] != "***********00" {
+		t.Errorf("Expected masked GSM number, got %v", buyer["gsmNumber"])
+	}
+
+	if redacted["conversationId"] != "123" {
+		t.Errorf("Expected conversation ID to be kept, got %v", redacted["conversationId"])
+	}
+
+	if request.PaymentCard.CVC != "123" {
+		t.Error("Expected the original request to be unchanged")
+	}
+}
+
+func TestRedactUntaggedSensitiveFields(t *testing.T) {
+	request := struct {
+		CardNumber     string `json:"cardNumber"`
+		CVC            string `json:"cvc"`
+		IdentityNumber string
+		Name           string `json:"name"`
+	}{
+		CardNumber:     "5528790000000008",
+		CVC:            "123",
+		IdentityNumber: "74300864791",
+		Name:           "John",
+	}
+
+	redacted := Redact(request).(map[string]interface{})
+	if redacted["cardNumber"] != "552879******0008" {
+		t.Errorf("Expected masked card number, got %v", redacted["cardNumber"])
+	}
+
+	if _, ok := redacted["cvc"]; ok {
+		t.Error("Expected cvc to be removed")
+	}
+
+	if redacted["IdentityNumber"] != "*********91" {
+		t.Errorf("Expected masked identity number, got %v", redacted["IdentityNumber"])
+	}
+
+	if redacted["name"] != "John" {
+		t.Errorf("Expected name to be kept, got %v", redacted["name"])
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "failure", "errorCode": "10051", "conversationId": "123"}`))
+	})
+	client.config.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client.Payment.Create(context.Background(), &PaymentRequest{
+		ConversationID: "123",
+		PaymentCard: &PaymentCard{
+			CardNumber: "5528790000000008",
+			CVC:        "123",
+		},
+		Buyer: &Buyer{IdentityNumber: "74300864791"},
+	})
+
+	output := buf.String()
+	for _, secret := range []string{"5528790000000008", `"cvc"`, "74300864791"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %s to be redacted from log output: %s", secret, output)
+		}
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected a single JSON log entry, got %s", output)
+	}
+
+	expected := map[string]interface{}{
+		"level":           "ERROR",
+		"operation":       "Payment.Create",
+		"endpoint":        EndpointPaymentAuth,
+		"conversation_id": "123",
+		"status":          "failure",
+		"error_code":      "10051",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Expected %s %v, got %v", key, value, entry[key])
+		}
+	}
+
+	if _, ok := entry["latency"]; !ok {
+		t.Error("Expected latency to be logged")
+	}
+}
@@ -0,0 +1,44 @@
+package iyzipay
+
+import (
+	"context"
+	"time"
+)
+
+// Operation describes a service call seen by middleware
+type Operation struct {
+	// Name is the service and method name, e.g. "Payment.Create"
+	Name     string
+	Method   string
+	Endpoint string
+}
+
+// CallFunc performs a service call. Request is the typed request, or nil for calls
+// without a body, and response is a pointer to the typed response, which is filled
+// in when CallFunc returns.
+type CallFunc func(ctx context.Context, op *Operation, request, response interface{}) error
+
+// Middleware wraps every service call. It may inspect the request and response,
+// change the context or return an error instead of calling next.
+type Middleware func(next CallFunc) CallFunc
+
+// Use adds middleware to the client. The first middleware added is the outermost.
+// Use is not safe to call concurrently with service calls; add middleware before making requests.
+func (c *Client) Use(middleware ...Middleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// intercept runs call through the middleware chain and logs the result
+func (c *Client) intercept(ctx context.Context, op *Operation, request, response interface{}, call func(ctx context.Context) error) error {
+	next := CallFunc(func(ctx context.Context, op *Operation, request, response interface{}) error {
+		return call(ctx)
+	})
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+
+	start := time.Now()
+	err := next(ctx, op, request, response)
+	c.logCall(ctx, op, request, response, time.Since(start), err)
+	return err
+}
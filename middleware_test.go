@@ -0,0 +1,120 @@
+package iyzipay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestClientUse(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "success", "conversationId": "123", "paymentId": "12345"}`))
+	})
+
+	var calls []string
+	var seenRequest *RetrievePaymentRequest
+	var seenResponse *PaymentResponse
+	client.Use(
+		func(next CallFunc) CallFunc {
+			return func(ctx context.Context, op *Operation, request, response interface{}) error {
+				calls = append(calls, "outer:"+op.Name)
+				err := next(ctx, op, request, response)
+				seenRequest, _ = request.(*RetrievePaymentRequest)
+				seenResponse, _ = response.(*PaymentResponse)
+				return err
+			}
+		},
+		func(next CallFunc) CallFunc {
+			return func(ctx context.Context, op *Operation, request, response interface{}) error {
+				calls = append(calls, "inner:"+op.Endpoint)
+				return next(ctx, op, request, response)
+			}
+		},
+	)
+
+	request := &RetrievePaymentRequest{ConversationID: "123", PaymentID: "12345"}
+	if _, err := client.Payment.Retrieve(context.Background(), request); err != nil {
+		t.Fatalf("Payment retrieve failed: %v", err)
+	}
+
+	expected := []string{"outer:Payment.Retrieve", "inner:" + EndpointPaymentDetail}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected calls %v, got %v", expected, calls)
+	}
+
+	if seenRequest != request {
+		t.Error("Expected middleware to see the typed request")
+	}
+
+	if seenResponse == nil || seenResponse.PaymentID != "12345" {
+		t.Errorf("Expected middleware to see the typed response, got %+v", seenResponse)
+	}
+}
+
+func TestClientUseShortCircuit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request to be sent")
+	})
+
+	errBlocked := errors.New("blocked by policy")
+	client.Use(func(next CallFunc) CallFunc {
+		return func(ctx context.Context, op *Operation, request, response interface{}) error {
+			if op.Name == "Refund.Create" {
+				return errBlocked
+			}
+			return next(ctx, op, request, response)
+		}
+	})
+
+	_, err := client.Refund.Create(context.Background(), &RefundRequest{ConversationID: "123"})
+	if !errors.Is(err, errBlocked) {
+		t.Errorf("Expected policy error, got %v", err)
+	}
+}
+
+func TestClientUseSeesErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "failure", "errorCode": "10051"}`))
+	})
+
+	var seenErr error
+	client.Use(func(next CallFunc) CallFunc {
+		return func(ctx context.Context, op *Operation, request, response interface{}) error {
+			seenErr = next(ctx, op, request, response)
+			return seenErr
+		}
+	})
+
+	_, err := client.Payment.Create(context.Background(), &PaymentRequest{ConversationID: "123"})
+	if !errors.Is(seenErr, ErrInsufficientFunds) || seenErr != err {
+		t.Errorf("Expected middleware to see the API error, got %v", seenErr)
+	}
+}
+
+func TestClientUseSeesValidationErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request to be sent")
+	})
+
+	var operations []string
+	client.Use(func(next CallFunc) CallFunc {
+		return func(ctx context.Context, op *Operation, request, response interface{}) error {
+			err := next(ctx, op, request, response)
+			if err == nil {
+				t.Errorf("Expected validation error for %s", op.Name)
+			}
+			operations = append(operations, op.Name)
+			return err
+		}
+	})
+
+	client.Subscription.RetrieveProduct(context.Background(), &RetrieveSubscriptionProductRequest{})
+	client.Subscription.InitializeCardUpdate(context.Background(), &CreateSubscriptionCardUpdateRequest{})
+
+	expected := []string{"Subscription.RetrieveProduct", "Subscription.InitializeCardUpdate"}
+	if !reflect.DeepEqual(operations, expected) {
+		t.Errorf("Expected operations %v, got %v", expected, operations)
+	}
+}
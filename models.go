@@ -24,9 +24,9 @@ type Buyer struct {
 	ID                  string `json:"id"`
 	Name                string `json:"name"`
 	Surname             string `json:"surname"`
-	IdentityNumber      string `json:"identityNumber"`
+	IdentityNumber      string `json:"identityNumber" redact:"mask"`
 	Email               string `json:"email"`
-	GsmNumber           string `json:"gsmNumber"`
+	GsmNumber           string `json:"gsmNumber" redact:"mask"`
 	RegistrationDate    string `json:"registrationDate"`
 	LastLoginDate       string `json:"lastLoginDate"`
 	RegistrationAddress string `json:"registrationAddress"`
@@ -39,10 +39,10 @@ type Buyer struct {
 // PaymentCard represents payment card information
 type PaymentCard struct {
 	CardHolderName       string `json:"cardHolderName"`
-	CardNumber           string `json:"cardNumber"`
-	ExpireYear           string `json:"expireYear"`
-	ExpireMonth          string `json:"expireMonth"`
-	CVC                  string `json:"cvc"`
+	CardNumber           string `json:"cardNumber" redact:"pan"`
+	ExpireYear           string `json:"expireYear" redact:"omit"`
+	ExpireMonth          string `json:"expireMonth" redact:"omit"`
+	CVC                  string `json:"cvc" redact:"omit"`
 	RegisterCard         *bool  `json:"registerCard,omitempty"`
 	CardAlias            string `json:"cardAlias"`
 	CardToken            string `json:"cardToken"`
@@ -55,10 +55,10 @@ type PaymentCard struct {
 // SubscriptionCard represents subscription card information
 type SubscriptionCard struct {
 	CardHolderName       string `json:"cardHolderName"`
-	CardNumber           string `json:"cardNumber"`
-	ExpireYear           string `json:"expireYear"`
-	ExpireMonth          string `json:"expireMonth"`
-	CVC                  string `json:"cvc"`
+	CardNumber           string `json:"cardNumber" redact:"pan"`
+	ExpireYear           string `json:"expireYear" redact:"omit"`
+	ExpireMonth          string `json:"expireMonth" redact:"omit"`
+	CVC                  string `json:"cvc" redact:"omit"`
 	CardUserKey          string `json:"cardUserKey"`
 	CardToken            string `json:"cardToken"`
 	UcsToken             string `json:"ucsToken"`
@@ -69,9 +69,9 @@ type SubscriptionCard struct {
 // CardInformation represents card information for storage
 type CardInformation struct {
 	CardAlias      string `json:"cardAlias"`
-	CardNumber     string `json:"cardNumber"`
-	ExpireYear     string `json:"expireYear"`
-	ExpireMonth    string `json:"expireMonth"`
+	CardNumber     string `json:"cardNumber" redact:"pan"`
+	ExpireYear     string `json:"expireYear" redact:"omit"`
+	ExpireMonth    string `json:"expireMonth" redact:"omit"`
 	CardHolderName string `json:"cardHolderName"`
 }
 
@@ -120,9 +120,9 @@ type Pagination struct {
 type SubscriptionCustomer struct {
 	Name            string               `json:"name"`
 	Surname         string               `json:"surname"`
-	IdentityNumber  string               `json:"identityNumber"`
+	IdentityNumber  string               `json:"identityNumber" redact:"mask"`
 	Email           string               `json:"email"`
-	GsmNumber       string               `json:"gsmNumber"`
+	GsmNumber       string               `json:"gsmNumber" redact:"mask"`
 	BillingAddress  *SubscriptionAddress `json:"billingAddress"`
 	ShippingAddress *SubscriptionAddress `json:"shippingAddress"`
 }
@@ -146,6 +146,8 @@ type SubscriptionPricingPlan struct {
 	PaymentIntervalCount int    `json:"paymentIntervalCount"`
 	TrialPeriodDays      int    `json:"trialPeriodDays"`
 	PlanPaymentType      string `json:"planPaymentType"`
+	RecurrenceCount      *int   `json:"recurrenceCount,omitempty"`
+	ProductReferenceCode string `json:"-"`
 }
 
 // PaymentRequest represents payment request
@@ -165,7 +167,7 @@ type PaymentRequest struct {
 	BillingAddress  *Address      `json:"billingAddress"`
 	BasketItems     []BasketItem  `json:"basketItems"`
 	PaymentSource   string        `json:"paymentSource"`
-	GsmNumber       string        `json:"gsmNumber"`
+	GsmNumber       string        `json:"gsmNumber" redact:"mask"`
 	PosOrderID      string        `json:"posOrderId"`
 	ConnectorName   string        `json:"connectorName"`
 	CallbackURL     string        `json:"callbackUrl"`
@@ -224,6 +226,16 @@ type RefundRequest struct {
 	Description          string `json:"description"`
 }
 
+// RefundChargedFromMerchantRequest represents refund request charged from the merchant balance
+type RefundChargedFromMerchantRequest struct {
+	Locale               string `json:"locale"`
+	ConversationID       string `json:"conversationId"`
+	PaymentTransactionID string `json:"paymentTransactionId"`
+	Price                string `json:"price"`
+	Currency             string `json:"currency"`
+	IP                   string `json:"ip"`
+}
+
 // CancelRequest represents cancel request
 type CancelRequest struct {
 	Locale         string `json:"locale"`
@@ -263,6 +275,7 @@ type PaymentResponse struct {
 	LastFourDigits      string `json:"lastFourDigits"`
 	PosOrderID          string `json:"posOrderId"`
 	PaymentSource       string `json:"paymentSource"`
+	PaymentStatus       string `json:"paymentStatus"`
 	ErrorCode           string `json:"errorCode"`
 	ErrorMessage        string `json:"errorMessage"`
 	ErrorGroup          string `json:"errorGroup"`
@@ -0,0 +1,199 @@
+package iyzipay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrPaymentNotSuccessful is returned when a retrieved payment does not have the SUCCESS status
+var ErrPaymentNotSuccessful = errors.New("iyzipay: payment not successful")
+
+// PaymentCallbackKind identifies the payment flow a callback handler serves
+type PaymentCallbackKind string
+
+// Payment callback kinds
+const (
+	PaymentCallbackCheckoutForm PaymentCallbackKind = "CHECKOUT_FORM"
+	PaymentCallbackBKM          PaymentCallbackKind = "BKM"
+	PaymentCallbackAPM          PaymentCallbackKind = "APM"
+)
+
+// PaymentCallbackResult represents the result of handling a payment callback.
+// Only the response field matching Kind is set.
+type PaymentCallbackResult struct {
+	Kind PaymentCallbackKind
+	// Reference is the posted token, or the payment ID for APM callbacks
+	Reference      string
+	ConversationID string
+
+	CheckoutForm *CheckoutFormResponse
+	BKM          *BKMResponse
+	BasicBKM     *BasicBKMResponse
+	APM          *APMResponse
+
+	Err error
+}
+
+// PaymentCallbackHandler is an http.Handler for the callback URL of the checkout form, BKM
+// and APM flows. It retrieves the payment for the posted reference, verifies its signature,
+// checks its conversation ID against the one stored at initialize time and requires the
+// SUCCESS payment status before calling OnSuccess. BKM payments are retrieved with
+// BKM.RetrieveBasic when Basic is set.
+type PaymentCallbackHandler struct {
+	client *Client
+	kind   PaymentCallbackKind
+
+	Basic  bool
+	Locale string
+
+	// LookupConversationID returns the conversation ID stored when the payment was
+	// initialized for reference, which is the token or, for APM, the payment ID
+	LookupConversationID func(ctx context.Context, reference string) (string, error)
+
+	OnSuccess func(w http.ResponseWriter, r *http.Request, result *PaymentCallbackResult)
+	OnFailure func(w http.ResponseWriter, r *http.Request, result *PaymentCallbackResult)
+}
+
+// NewCheckoutFormCallbackHandler creates a callback handler for the checkout form
+func (c *Client) NewCheckoutFormCallbackHandler() *PaymentCallbackHandler {
+	return &PaymentCallbackHandler{client: c, kind: PaymentCallbackCheckoutForm}
+}
+
+// NewBKMCallbackHandler creates a callback handler for BKM Express
+func (c *Client) NewBKMCallbackHandler() *PaymentCallbackHandler {
+	return &PaymentCallbackHandler{client: c, kind: PaymentCallbackBKM}
+}
+
+// NewAPMCallbackHandler creates a callback handler for alternative payment methods
+func (c *Client) NewAPMCallbackHandler() *PaymentCallbackHandler {
+	return &PaymentCallbackHandler{client: c, kind: PaymentCallbackAPM}
+}
+
+// ServeHTTP implements http.Handler
+func (h *PaymentCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	result := &PaymentCallbackResult{Kind: h.kind}
+	if err := r.ParseForm(); err != nil {
+		result.Err = fmt.Errorf("failed to parse payment callback: %w", err)
+		h.fail(w, r, http.StatusBadRequest, result)
+		return
+	}
+
+	field := "token"
+	if h.kind == PaymentCallbackAPM {
+		field = "paymentId"
+	}
+	result.Reference = r.PostFormValue(field)
+	if result.Reference == "" {
+		result.Err = fmt.Errorf("payment callback is missing %s", field)
+		h.fail(w, r, http.StatusBadRequest, result)
+		return
+	}
+
+	if h.LookupConversationID == nil {
+		result.Err = errors.New("iyzipay: LookupConversationID is not set")
+		h.fail(w, r, http.StatusInternalServerError, result)
+		return
+	}
+
+	conversationID, err := h.LookupConversationID(r.Context(), result.Reference)
+	if err != nil {
+		result.Err = err
+		h.fail(w, r, http.StatusBadRequest, result)
+		return
+	}
+	result.ConversationID = conversationID
+
+	if err := h.retrieve(r.Context(), result); err != nil {
+		result.Err = err
+		h.fail(w, r, http.StatusPaymentRequired, result)
+		return
+	}
+
+	if h.OnSuccess != nil {
+		h.OnSuccess(w, r, result)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// retrieve looks up the payment for the callback and checks that it can be treated as paid
+func (h *PaymentCallbackHandler) retrieve(ctx context.Context, result *PaymentCallbackResult) error {
+	var (
+		signed         signedResponse
+		conversationID string
+		paymentStatus  string
+		err            error
+	)
+
+	switch h.kind {
+	case PaymentCallbackCheckoutForm:
+		result.CheckoutForm, err = h.client.CheckoutForm.Retrieve(ctx, &RetrieveCheckoutFormRequest{
+			Locale:         h.Locale,
+			ConversationID: result.ConversationID,
+			Token:          result.Reference,
+		})
+		if err != nil {
+			return err
+		}
+		signed, conversationID, paymentStatus = result.CheckoutForm, result.CheckoutForm.ConversationID, result.CheckoutForm.PaymentStatus
+	case PaymentCallbackBKM:
+		request := &RetrieveBKMRequest{
+			Locale:         h.Locale,
+			ConversationID: result.ConversationID,
+			Token:          result.Reference,
+		}
+		if h.Basic {
+			result.BasicBKM, err = h.client.BKM.RetrieveBasic(ctx, request)
+			if err != nil {
+				return err
+			}
+			signed, conversationID, paymentStatus = result.BasicBKM, result.BasicBKM.ConversationID, result.BasicBKM.PaymentStatus
+			break
+		}
+		result.BKM, err = h.client.BKM.Retrieve(ctx, request)
+		if err != nil {
+			return err
+		}
+		signed, conversationID, paymentStatus = result.BKM, result.BKM.ConversationID, result.BKM.PaymentStatus
+	case PaymentCallbackAPM:
+		result.APM, err = h.client.APM.Retrieve(ctx, &RetrieveAPMRequest{
+			Locale:         h.Locale,
+			ConversationID: result.ConversationID,
+			PaymentID:      result.Reference,
+		})
+		if err != nil {
+			return err
+		}
+		signed, conversationID, paymentStatus = result.APM, result.APM.ConversationID, result.APM.PaymentStatus
+	default:
+		return fmt.Errorf("unsupported payment callback kind %q", h.kind)
+	}
+
+	if err := signed.VerifySignature(h.client.config.SecretKey); err != nil {
+		return err
+	}
+	if conversationID != result.ConversationID {
+		return ErrConversationMismatch
+	}
+	if paymentStatus != PaymentStatusSuccess {
+		return fmt.Errorf("%w: status %s", ErrPaymentNotSuccessful, paymentStatus)
+	}
+	return nil
+}
+
+// fail reports a failed callback to OnFailure, or responds with status when it is not set
+func (h *PaymentCallbackHandler) fail(w http.ResponseWriter, r *http.Request, status int, result *PaymentCallbackResult) {
+	if h.OnFailure != nil {
+		h.OnFailure(w, r, result)
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
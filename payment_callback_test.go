@@ -0,0 +1,158 @@
+package iyzipay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestCheckoutFormCallbackHandler(t *testing.T) {
+	secretKey := "test-secret-key"
+
+	tests := []struct {
+		name           string
+		paymentStatus  string
+		conversationID string
+		tamper         bool
+		wantErr        error
+	}{
+		{name: "paid", paymentStatus: PaymentStatusSuccess, conversationID: "123"},
+		{name: "not paid", paymentStatus: PaymentStatusFailure, conversationID: "123", wantErr: ErrPaymentNotSuccessful},
+		{name: "other conversation", paymentStatus: PaymentStatusSuccess, conversationID: "456", wantErr: ErrConversationMismatch},
+		{name: "tampered", paymentStatus: PaymentStatusSuccess, conversationID: "123", tamper: true, wantErr: ErrSignatureMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != EndpointCheckoutFormAuthDetail {
+					t.Errorf("Expected path %s, got %s", EndpointCheckoutFormAuthDetail, r.URL.Path)
+				}
+				signature := calculateHMACSignature([]string{tt.paymentStatus, "12345", CurrencyTRY, "B1", tt.conversationID, "1.2", "1", "tok"}, secretKey)
+				paidPrice := "1.2"
+				if tt.tamper {
+					paidPrice = "0.2"
+				}
+				fmt.Fprintf(w, `{"status": "success", "conversationId": %q, "token": "tok", "paymentStatus": %q, "paymentId": "12345", "currency": "TRY", "basketId": "B1", "paidPrice": %q, "price": "1", "signature": %q}`,
+					tt.conversationID, tt.paymentStatus, paidPrice, signature)
+			})
+
+			var result *PaymentCallbackResult
+			var succeeded bool
+			handler := client.NewCheckoutFormCallbackHandler()
+			handler.LookupConversationID = func(ctx context.Context, token string) (string, error) {
+				if token != "tok" {
+					t.Errorf("Expected token tok, got %s", token)
+				}
+				return "123", nil
+			}
+			handler.OnSuccess = func(w http.ResponseWriter, r *http.Request, res *PaymentCallbackResult) {
+				succeeded = true
+				result = res
+			}
+			handler.OnFailure = func(w http.ResponseWriter, r *http.Request, res *PaymentCallbackResult) {
+				result = res
+			}
+
+			postCallback(handler, url.Values{"token": {"tok"}})
+
+			if result == nil {
+				t.Fatal("Expected a callback to be called")
+			}
+
+			if !errors.Is(result.Err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, result.Err)
+			}
+
+			if succeeded != (tt.wantErr == nil) {
+				t.Errorf("Expected success=%t, got %t", tt.wantErr == nil, succeeded)
+			}
+
+			if result.CheckoutForm == nil || result.CheckoutForm.PaymentID != "12345" {
+				t.Errorf("Expected checkout form response, got %+v", result.CheckoutForm)
+			}
+		})
+	}
+}
+
+func TestAPMCallbackHandler(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		signature := calculateHMACSignature([]string{"12345", CurrencyTRY, "B1", "123", "1.2", "1"}, "test-secret-key")
+		fmt.Fprintf(w, `{"status": "success", "conversationId": "123", "paymentId": "12345", "paymentStatus": "SUCCESS", "currency": "TRY", "basketId": "B1", "paidPrice": "1.2", "price": "1", "signature": %q}`, signature)
+	})
+
+	handler := client.NewAPMCallbackHandler()
+	handler.LookupConversationID = func(ctx context.Context, paymentID string) (string, error) {
+		return "123", nil
+	}
+
+	rec := postCallback(handler, url.Values{"paymentId": {"12345"}})
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestBKMCallbackHandlerBasic(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointBKMAuthDetailBasic {
+			t.Errorf("Expected path %s, got %s", EndpointBKMAuthDetailBasic, r.URL.Path)
+		}
+		signature := calculateHMACSignature([]string{"12345", PaymentStatusSuccess, "123", CurrencyTRY, "1.2", "1", "tok"}, "test-secret-key")
+		fmt.Fprintf(w, `{"status": "success", "conversationId": "123", "token": "tok", "paymentId": "12345", "paymentStatus": "SUCCESS", "currency": "TRY", "paidPrice": "1.2", "price": "1", "signature": %q}`, signature)
+	})
+
+	var result *PaymentCallbackResult
+	handler := client.NewBKMCallbackHandler()
+	handler.Basic = true
+	handler.LookupConversationID = func(ctx context.Context, token string) (string, error) {
+		return "123", nil
+	}
+	handler.OnSuccess = func(w http.ResponseWriter, r *http.Request, res *PaymentCallbackResult) {
+		result = res
+	}
+
+	postCallback(handler, url.Values{"token": {"tok"}})
+
+	if result == nil {
+		t.Fatal("Expected OnSuccess to be called")
+	}
+
+	if result.BasicBKM == nil || result.BasicBKM.PaymentID != "12345" {
+		t.Errorf("Expected basic BKM response, got %+v", result.BasicBKM)
+	}
+
+	if result.BKM != nil {
+		t.Errorf("Expected no BKM response, got %+v", result.BKM)
+	}
+}
+
+func TestPaymentCallbackHandlerReject(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no API call")
+	})
+
+	tests := []struct {
+		name       string
+		form       url.Values
+		lookup     func(ctx context.Context, token string) (string, error)
+		wantStatus int
+	}{
+		{name: "missing token", form: url.Values{}, lookup: func(ctx context.Context, token string) (string, error) { return "123", nil }, wantStatus: http.StatusBadRequest},
+		{name: "unknown token", form: url.Values{"token": {"tok"}}, lookup: func(ctx context.Context, token string) (string, error) { return "", errors.New("not found") }, wantStatus: http.StatusBadRequest},
+		{name: "lookup not set", form: url.Values{"token": {"tok"}}, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := client.NewBKMCallbackHandler()
+			handler.LookupConversationID = tt.lookup
+
+			if rec := postCallback(handler, tt.form); rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
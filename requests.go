@@ -4,10 +4,22 @@ package iyzipay
 
 // RetrievePaymentRequest represents retrieve payment request
 type RetrievePaymentRequest struct {
+	Locale                string `json:"locale"`
+	ConversationID        string `json:"conversationId"`
+	PaymentID             string `json:"paymentId"`
+	PaymentConversationID string `json:"paymentConversationId,omitempty"`
+	IP                    string `json:"ip"`
+}
+
+// CreatePostAuthRequest represents post-authorization (capture) request.
+// PaidPrice may be lower than the pre-authorized amount.
+type CreatePostAuthRequest struct {
 	Locale         string `json:"locale"`
 	ConversationID string `json:"conversationId"`
 	PaymentID      string `json:"paymentId"`
+	PaidPrice      string `json:"paidPrice"`
 	IP             string `json:"ip"`
+	Currency       string `json:"currency"`
 }
 
 // ThreedsInitializeResponse represents 3DS initialize response
@@ -81,9 +93,15 @@ type CheckoutFormResponse struct {
 	BasketID            string            `json:"basketId"`
 	ItemTransactions    []ItemTransaction `json:"itemTransactions"`
 	MdStatus            int               `json:"mdStatus"`
+	Phase               string            `json:"phase"`
 	Signature           string            `json:"signature"`
 }
 
+// IsPreAuth reports whether the checkout form result is a pre-authorization
+func (r *CheckoutFormResponse) IsPreAuth() bool {
+	return r.Phase == PaymentPhasePreAuth
+}
+
 // CreateCardRequest represents create card request
 type CreateCardRequest struct {
 	Locale         string           `json:"locale"`
@@ -155,6 +173,18 @@ type RefundResponse struct {
 	HostReference        string `json:"hostReference"`
 }
 
+// RefundChargedFromMerchantResponse represents refund charged from merchant response
+type RefundChargedFromMerchantResponse struct {
+	BaseResponse
+	PaymentID            string `json:"paymentId"`
+	PaymentTransactionID string `json:"paymentTransactionId"`
+	Price                string `json:"price"`
+	Currency             string `json:"currency"`
+	ConnectorName        string `json:"connectorName"`
+	AuthCode             string `json:"authCode"`
+	HostReference        string `json:"hostReference"`
+}
+
 // CancelResponse represents cancel response
 type CancelResponse struct {
 	BaseResponse
@@ -176,13 +206,13 @@ type CreateSubMerchantRequest struct {
 	ContactName           string `json:"contactName"`
 	ContactSurname        string `json:"contactSurname"`
 	Email                 string `json:"email"`
-	GsmNumber             string `json:"gsmNumber"`
+	GsmNumber             string `json:"gsmNumber" redact:"mask"`
 	Name                  string `json:"name"`
-	IBAN                  string `json:"iban"`
-	IdentityNumber        string `json:"identityNumber"`
+	IBAN                  string `json:"iban" redact:"mask"`
+	IdentityNumber        string `json:"identityNumber" redact:"mask"`
 	Currency              string `json:"currency"`
 	TaxOffice             string `json:"taxOffice"`
-	TaxNumber             string `json:"taxNumber"`
+	TaxNumber             string `json:"taxNumber" redact:"mask"`
 	LegalCompanyTitle     string `json:"legalCompanyTitle"`
 	SwiftCode             string `json:"swiftCode"`
 }
@@ -192,17 +222,17 @@ type UpdateSubMerchantRequest struct {
 	Locale                string `json:"locale"`
 	ConversationID        string `json:"conversationId"`
 	SubMerchantKey        string `json:"subMerchantKey"`
-	IBAN                  string `json:"iban"`
+	IBAN                  string `json:"iban" redact:"mask"`
 	Address               string `json:"address"`
 	ContactName           string `json:"contactName"`
 	ContactSurname        string `json:"contactSurname"`
 	Email                 string `json:"email"`
-	GsmNumber             string `json:"gsmNumber"`
+	GsmNumber             string `json:"gsmNumber" redact:"mask"`
 	Name                  string `json:"name"`
-	IdentityNumber        string `json:"identityNumber"`
+	IdentityNumber        string `json:"identityNumber" redact:"mask"`
 	Currency              string `json:"currency"`
 	TaxOffice             string `json:"taxOffice"`
-	TaxNumber             string `json:"taxNumber"`
+	TaxNumber             string `json:"taxNumber" redact:"mask"`
 	LegalCompanyTitle     string `json:"legalCompanyTitle"`
 	SwiftCode             string `json:"swiftCode"`
 }
@@ -224,10 +254,10 @@ type SubMerchantResponse struct {
 	ContactName           string `json:"contactName"`
 	ContactSurname        string `json:"contactSurname"`
 	Email                 string `json:"email"`
-	GsmNumber             string `json:"gsmNumber"`
+	GsmNumber             string `json:"gsmNumber" redact:"mask"`
 	Name                  string `json:"name"`
-	IBAN                  string `json:"iban"`
-	IdentityNumber        string `json:"identityNumber"`
+	IBAN                  string `json:"iban" redact:"mask"`
+	IdentityNumber        string `json:"identityNumber" redact:"mask"`
 	Currency              string `json:"currency"`
 }
 
@@ -268,7 +298,9 @@ type BKMInitializeResponse struct {
 	Signature   string `json:"signature"`
 }
 
-// RetrieveBKMRequest represents retrieve BKM request
+// RetrieveBKMRequest represents retrieve BKM request.
+// Token is the value posted to the callback URL and is accepted by both
+// BKMService.Retrieve and BKMService.RetrieveBasic.
 type RetrieveBKMRequest struct {
 	Locale         string `json:"locale"`
 	ConversationID string `json:"conversationId"`
@@ -290,6 +322,32 @@ type BKMResponse struct {
 	Signature           string            `json:"signature"`
 }
 
+// BasicBKMResponse represents basic BKM response
+type BasicBKMResponse struct {
+	BaseResponse
+	Token              string `json:"token"`
+	CallbackURL        string `json:"callbackUrl"`
+	PaymentStatus      string `json:"paymentStatus"`
+	PaymentID          string `json:"paymentId"`
+	Price              string `json:"price"`
+	PaidPrice          string `json:"paidPrice"`
+	Installment        int    `json:"installment"`
+	Currency           string `json:"currency"`
+	MerchantCommission string `json:"merchantCommissionRate"`
+	IyziCommission     string `json:"iyziCommissionRateAmount"`
+	IyziCommissionFee  string `json:"iyziCommissionFee"`
+	CardType           string `json:"cardType"`
+	CardAssociation    string `json:"cardAssociation"`
+	CardFamily         string `json:"cardFamily"`
+	BinNumber          string `json:"binNumber"`
+	LastFourDigits     string `json:"lastFourDigits"`
+	ConnectorName      string `json:"connectorName"`
+	AuthCode           string `json:"authCode"`
+	Phase              string `json:"phase"`
+	PosOrderID         string `json:"posOrderId"`
+	Signature          string `json:"signature"`
+}
+
 // RetrieveAPMRequest represents retrieve APM request
 type RetrieveAPMRequest struct {
 	Locale         string `json:"locale"`
@@ -320,6 +378,39 @@ type APMResponse struct {
 	Signature           string            `json:"signature"`
 }
 
+// CreatePeccoInitializeRequest represents Pecco initialize request
+type CreatePeccoInitializeRequest struct {
+	Locale          string       `json:"locale"`
+	ConversationID  string       `json:"conversationId"`
+	Price           string       `json:"price"`
+	PaidPrice       string       `json:"paidPrice"`
+	Currency        string       `json:"currency"`
+	BasketID        string       `json:"basketId"`
+	PaymentGroup    string       `json:"paymentGroup"`
+	PaymentSource   string       `json:"paymentSource"`
+	CallbackURL     string       `json:"callbackUrl"`
+	Buyer           *Buyer       `json:"buyer"`
+	ShippingAddress *Address     `json:"shippingAddress"`
+	BillingAddress  *Address     `json:"billingAddress"`
+	BasketItems     []BasketItem `json:"basketItems"`
+}
+
+// PeccoInitializeResponse represents Pecco initialize response
+type PeccoInitializeResponse struct {
+	BaseResponse
+	HtmlContent     string `json:"htmlContent"`
+	RedirectURL     string `json:"redirectUrl"`
+	Token           string `json:"token"`
+	TokenExpireTime int64  `json:"tokenExpireTime"`
+}
+
+// CreatePeccoPaymentRequest represents Pecco payment auth request
+type CreatePeccoPaymentRequest struct {
+	Locale         string `json:"locale"`
+	ConversationID string `json:"conversationId"`
+	Token          string `json:"token"`
+}
+
 // CreateSubscriptionInitRequest represents subscription init request
 type CreateSubscriptionInitRequest struct {
 	Locale                    string               `json:"locale"`
@@ -330,12 +421,22 @@ type CreateSubscriptionInitRequest struct {
 	PaymentCard               *SubscriptionCard     `json:"paymentCard"`
 }
 
+// CreateSubscriptionInitWithCustomerRequest represents subscription init request for an existing customer
+type CreateSubscriptionInitWithCustomerRequest struct {
+	Locale                    string `json:"locale"`
+	ConversationID            string `json:"conversationId"`
+	PricingPlanReferenceCode  string `json:"pricingPlanReferenceCode"`
+	SubscriptionInitialStatus string `json:"subscriptionInitialStatus"`
+	CustomerReferenceCode     string `json:"customerReferenceCode"`
+}
+
 // SubscriptionInitializeResponse represents subscription initialize response
 type SubscriptionInitializeResponse struct {
 	BaseResponse
 	SubscriptionReferenceCode string `json:"subscriptionReferenceCode"`
 	ParentReferenceCode       string `json:"parentReferenceCode"`
 	PricingPlanReferenceCode  string `json:"pricingPlanReferenceCode"`
+	CustomerReferenceCode     string `json:"customerReferenceCode"`
 	SubscriptionStatus        string `json:"subscriptionStatus"`
 	TrialDays                 int    `json:"trialDays"`
 	TrialStartDate            string `json:"trialStartDate"`
@@ -344,6 +445,347 @@ type SubscriptionInitializeResponse struct {
 	EndDate                   string `json:"endDate"`
 }
 
+// UpdateSubscriptionProductRequest represents subscription product update request
+type UpdateSubscriptionProductRequest struct {
+	Locale               string `json:"locale"`
+	ConversationID       string `json:"conversationId"`
+	ProductReferenceCode string `json:"-"`
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+}
+
+// RetrieveSubscriptionProductRequest represents subscription product retrieve request
+type RetrieveSubscriptionProductRequest struct {
+	ProductReferenceCode string `json:"-"`
+}
+
+// DeleteSubscriptionProductRequest represents subscription product delete request
+type DeleteSubscriptionProductRequest struct {
+	Locale               string `json:"locale"`
+	ConversationID       string `json:"conversationId"`
+	ProductReferenceCode string `json:"-"`
+}
+
+// SubscriptionProductResponse represents subscription product response
+type SubscriptionProductResponse struct {
+	BaseResponse
+	Data SubscriptionProductData `json:"data"`
+}
+
+// SubscriptionProductListResponse represents paginated subscription product list response
+type SubscriptionProductListResponse struct {
+	BaseResponse
+	Data SubscriptionProductPage `json:"data"`
+}
+
+// SubscriptionProductPage represents a page of subscription products
+type SubscriptionProductPage struct {
+	TotalCount  int                       `json:"totalCount"`
+	CurrentPage int                       `json:"currentPage"`
+	PageCount   int                       `json:"pageCount"`
+	Items       []SubscriptionProductData `json:"items"`
+}
+
+// SubscriptionProductData represents subscription product details
+type SubscriptionProductData struct {
+	ReferenceCode string                        `json:"referenceCode"`
+	CreatedDate   int64                         `json:"createdDate"`
+	Name          string                        `json:"name"`
+	Description   string                        `json:"description"`
+	Status        string                        `json:"status"`
+	PricingPlans  []SubscriptionPricingPlanData `json:"pricingPlans"`
+}
+
+// SubscriptionPricingPlanData represents subscription pricing plan details
+type SubscriptionPricingPlanData struct {
+	ReferenceCode        string `json:"referenceCode"`
+	CreatedDate          int64  `json:"createdDate"`
+	Name                 string `json:"name"`
+	Price                string `json:"price"`
+	PaymentInterval      string `json:"paymentInterval"`
+	PaymentIntervalCount int    `json:"paymentIntervalCount"`
+	TrialPeriodDays      int    `json:"trialPeriodDays"`
+	CurrencyCode         string `json:"currencyCode"`
+	ProductReferenceCode string `json:"productReferenceCode"`
+	PlanPaymentType      string `json:"planPaymentType"`
+	Status               string `json:"status"`
+	RecurrenceCount      int    `json:"recurrenceCount"`
+}
+
+// UpdateSubscriptionPricingPlanRequest represents subscription pricing plan update request
+type UpdateSubscriptionPricingPlanRequest struct {
+	Locale                   string `json:"locale"`
+	ConversationID           string `json:"conversationId"`
+	PricingPlanReferenceCode string `json:"-"`
+	Name                     string `json:"name"`
+	TrialPeriodDays          int    `json:"trialPeriodDays"`
+}
+
+// RetrieveSubscriptionPricingPlanRequest represents subscription pricing plan retrieve request
+type RetrieveSubscriptionPricingPlanRequest struct {
+	PricingPlanReferenceCode string `json:"-"`
+}
+
+// DeleteSubscriptionPricingPlanRequest represents subscription pricing plan delete request
+type DeleteSubscriptionPricingPlanRequest struct {
+	Locale                   string `json:"locale"`
+	ConversationID           string `json:"conversationId"`
+	PricingPlanReferenceCode string `json:"-"`
+}
+
+// RetrieveSubscriptionPricingPlansRequest represents paginated pricing plan list request for a product.
+// Page and Count are sent as query parameters.
+type RetrieveSubscriptionPricingPlansRequest struct {
+	ProductReferenceCode string `json:"-"`
+	Page                 int
+	Count                int
+}
+
+// SubscriptionPricingPlanResponse represents subscription pricing plan response
+type SubscriptionPricingPlanResponse struct {
+	BaseResponse
+	Data SubscriptionPricingPlanData `json:"data"`
+}
+
+// SubscriptionPricingPlanListResponse represents paginated subscription pricing plan list response
+type SubscriptionPricingPlanListResponse struct {
+	BaseResponse
+	Data SubscriptionPricingPlanPage `json:"data"`
+}
+
+// SubscriptionPricingPlanPage represents a page of subscription pricing plans
+type SubscriptionPricingPlanPage struct {
+	TotalCount  int                           `json:"totalCount"`
+	CurrentPage int                           `json:"currentPage"`
+	PageCount   int                           `json:"pageCount"`
+	Items       []SubscriptionPricingPlanData `json:"items"`
+}
+
+// CreateSubscriptionCustomerRequest represents subscription customer create request
+type CreateSubscriptionCustomerRequest struct {
+	Locale          string               `json:"locale"`
+	ConversationID  string               `json:"conversationId"`
+	Name            string               `json:"name"`
+	Surname         string               `json:"surname"`
+	IdentityNumber  string               `json:"identityNumber" redact:"mask"`
+	Email           string               `json:"email"`
+	GsmNumber       string               `json:"gsmNumber" redact:"mask"`
+	BillingAddress  *SubscriptionAddress `json:"billingAddress"`
+	ShippingAddress *SubscriptionAddress `json:"shippingAddress"`
+}
+
+// UpdateSubscriptionCustomerRequest represents subscription customer update request
+type UpdateSubscriptionCustomerRequest struct {
+	Locale                string               `json:"locale"`
+	ConversationID        string               `json:"conversationId"`
+	CustomerReferenceCode string               `json:"-"`
+	Name                  string               `json:"name"`
+	Surname               string               `json:"surname"`
+	IdentityNumber        string               `json:"identityNumber" redact:"mask"`
+	Email                 string               `json:"email"`
+	GsmNumber             string               `json:"gsmNumber" redact:"mask"`
+	BillingAddress        *SubscriptionAddress `json:"billingAddress"`
+	ShippingAddress       *SubscriptionAddress `json:"shippingAddress"`
+}
+
+// RetrieveSubscriptionCustomerRequest represents subscription customer retrieve request
+type RetrieveSubscriptionCustomerRequest struct {
+	CustomerReferenceCode string `json:"-"`
+}
+
+// SubscriptionCustomerResponse represents subscription customer response
+type SubscriptionCustomerResponse struct {
+	BaseResponse
+	Data SubscriptionCustomerData `json:"data"`
+}
+
+// SubscriptionCustomerListResponse represents paginated subscription customer list response
+type SubscriptionCustomerListResponse struct {
+	BaseResponse
+	Data SubscriptionCustomerPage `json:"data"`
+}
+
+// SubscriptionCustomerPage represents a page of subscription customers
+type SubscriptionCustomerPage struct {
+	TotalCount  int                        `json:"totalCount"`
+	CurrentPage int                        `json:"currentPage"`
+	PageCount   int                        `json:"pageCount"`
+	Items       []SubscriptionCustomerData `json:"items"`
+}
+
+// SubscriptionCustomerData represents subscription customer details.
+// ReferenceCode is the customerReferenceCode used by other subscription calls.
+type SubscriptionCustomerData struct {
+	ReferenceCode    string               `json:"referenceCode"`
+	CreatedDate      int64                `json:"createdDate"`
+	Status           string               `json:"status"`
+	Name             string               `json:"name"`
+	Surname          string               `json:"surname"`
+	IdentityNumber   string               `json:"identityNumber" redact:"mask"`
+	Email            string               `json:"email"`
+	GsmNumber        string               `json:"gsmNumber" redact:"mask"`
+	ContactEmail     string               `json:"contactEmail"`
+	ContactGsmNumber string               `json:"contactGsmNumber" redact:"mask"`
+	BillingAddress   *SubscriptionAddress `json:"billingAddress"`
+	ShippingAddress  *SubscriptionAddress `json:"shippingAddress"`
+}
+
+// CancelSubscriptionRequest represents subscription cancel request
+type CancelSubscriptionRequest struct {
+	Locale                    string `json:"locale"`
+	ConversationID            string `json:"conversationId"`
+	SubscriptionReferenceCode string `json:"-"`
+}
+
+// ActivateSubscriptionRequest represents subscription activate request
+type ActivateSubscriptionRequest struct {
+	Locale                    string `json:"locale"`
+	ConversationID            string `json:"conversationId"`
+	SubscriptionReferenceCode string `json:"-"`
+}
+
+// UpgradeSubscriptionRequest represents subscription upgrade request
+type UpgradeSubscriptionRequest struct {
+	Locale                      string `json:"locale"`
+	ConversationID              string `json:"conversationId"`
+	SubscriptionReferenceCode   string `json:"-"`
+	NewPricingPlanReferenceCode string `json:"newPricingPlanReferenceCode"`
+	UpgradePeriod               string `json:"upgradePeriod"`
+	UseTrial                    *bool  `json:"useTrial,omitempty"`
+	ResetRecurrenceCount        *bool  `json:"resetRecurrenceCount,omitempty"`
+}
+
+// RetrieveSubscriptionRequest represents subscription retrieve request
+type RetrieveSubscriptionRequest struct {
+	SubscriptionReferenceCode string `json:"-"`
+}
+
+// RetrySubscriptionPaymentRequest represents failed subscription payment retry request.
+// ReferenceCode is the reference code of the unpaid subscription order.
+type RetrySubscriptionPaymentRequest struct {
+	Locale         string `json:"locale"`
+	ConversationID string `json:"conversationId"`
+	ReferenceCode  string `json:"referenceCode"`
+}
+
+// SearchSubscriptionRequest represents subscription search request. Non-empty fields are
+// sent as query parameters. StartDate and EndDate are in yyyy-MM-dd format.
+type SearchSubscriptionRequest struct {
+	SubscriptionReferenceCode string
+	CustomerReferenceCode     string
+	PricingPlanReferenceCode  string
+	ParentReferenceCode       string
+	SubscriptionStatus        string
+	StartDate                 string
+	EndDate                   string
+	Page                      int
+	Count                     int
+}
+
+// SubscriptionResponse represents single subscription response
+type SubscriptionResponse struct {
+	BaseResponse
+	Data SubscriptionData `json:"data"`
+}
+
+// SubscriptionListResponse represents paginated subscription list response
+type SubscriptionListResponse struct {
+	BaseResponse
+	Data SubscriptionPage `json:"data"`
+}
+
+// Subscription returns the first subscription in the response, or nil if there is none
+func (r *SubscriptionListResponse) Subscription() *SubscriptionData {
+	if len(r.Data.Items) == 0 {
+		return nil
+	}
+	return &r.Data.Items[0]
+}
+
+// SubscriptionPage represents a page of subscriptions
+type SubscriptionPage struct {
+	TotalCount  int                `json:"totalCount"`
+	CurrentPage int                `json:"currentPage"`
+	PageCount   int                `json:"pageCount"`
+	Items       []SubscriptionData `json:"items"`
+}
+
+// SubscriptionData represents subscription details
+type SubscriptionData struct {
+	ReferenceCode            string              `json:"referenceCode"`
+	ParentReferenceCode      string              `json:"parentReferenceCode"`
+	PricingPlanName          string              `json:"pricingPlanName"`
+	PricingPlanReferenceCode string              `json:"pricingPlanReferenceCode"`
+	ProductName              string              `json:"productName"`
+	ProductReferenceCode     string              `json:"productReferenceCode"`
+	CustomerEmail            string              `json:"customerEmail"`
+	CustomerGsmNumber        string              `json:"customerGsmNumber" redact:"mask"`
+	CustomerReferenceCode    string              `json:"customerReferenceCode"`
+	SubscriptionStatus       string              `json:"subscriptionStatus"`
+	TrialDays                int                 `json:"trialDays"`
+	TrialStartDate           int64               `json:"trialStartDate"`
+	TrialEndDate             int64               `json:"trialEndDate"`
+	CreatedDate              int64               `json:"createdDate"`
+	StartDate                int64               `json:"startDate"`
+	EndDate                  int64               `json:"endDate"`
+	Orders                   []SubscriptionOrder `json:"orders"`
+}
+
+// SubscriptionOrder represents a subscription order (billing period)
+type SubscriptionOrder struct {
+	ReferenceCode   string                       `json:"referenceCode"`
+	Price           string                       `json:"price"`
+	CurrencyCode    string                       `json:"currencyCode"`
+	StartPeriod     int64                        `json:"startPeriod"`
+	EndPeriod       int64                        `json:"endPeriod"`
+	OrderStatus     string                       `json:"orderStatus"`
+	PaymentAttempts []SubscriptionPaymentAttempt `json:"paymentAttempts"`
+}
+
+// SubscriptionPaymentAttempt represents a payment attempt for a subscription order
+type SubscriptionPaymentAttempt struct {
+	PaymentID      string `json:"paymentId"`
+	CreatedDate    int64  `json:"createdDate"`
+	PaymentStatus  string `json:"paymentStatus"`
+	ConversationID string `json:"conversationId"`
+	ErrorCode      string `json:"errorCode"`
+	ErrorMessage   string `json:"errorMessage"`
+}
+
+// CreateSubscriptionCheckoutFormRequest represents subscription checkout form initialize request
+type CreateSubscriptionCheckoutFormRequest struct {
+	Locale                    string                `json:"locale"`
+	ConversationID            string                `json:"conversationId"`
+	CallbackURL               string                `json:"callbackUrl"`
+	PricingPlanReferenceCode  string                `json:"pricingPlanReferenceCode"`
+	SubscriptionInitialStatus string                `json:"subscriptionInitialStatus"`
+	Customer                  *SubscriptionCustomer `json:"customer"`
+}
+
+// RetrieveSubscriptionCheckoutFormRequest represents subscription checkout form retrieve request
+type RetrieveSubscriptionCheckoutFormRequest struct {
+	Token string `json:"-"`
+}
+
+// CreateSubscriptionCardUpdateRequest represents subscription card update form initialize request.
+// Set CustomerReferenceCode to update the customer's card, or SubscriptionReferenceCode
+// to update the card of a single subscription.
+type CreateSubscriptionCardUpdateRequest struct {
+	Locale                    string `json:"locale"`
+	ConversationID            string `json:"conversationId"`
+	CallbackURL               string `json:"callbackUrl"`
+	CustomerReferenceCode     string `json:"customerReferenceCode,omitempty"`
+	SubscriptionReferenceCode string `json:"subscriptionReferenceCode,omitempty"`
+}
+
+// SubscriptionCheckoutFormInitializeResponse represents subscription checkout and card update form initialize response
+type SubscriptionCheckoutFormInitializeResponse struct {
+	BaseResponse
+	Token               string `json:"token"`
+	CheckoutFormContent string `json:"checkoutFormContent"`
+	TokenExpireTime     int64  `json:"tokenExpireTime"`
+}
+
 // RetrieveInstallmentInfoRequest represents retrieve installment info request
 type RetrieveInstallmentInfoRequest struct {
 	Locale         string `json:"locale"`
@@ -358,6 +800,12 @@ type InstallmentInfoResponse struct {
 	InstallmentDetails []InstallmentDetail `json:"installmentDetails"`
 }
 
+// InstallmentHTMLResponse represents installment table HTML response
+type InstallmentHTMLResponse struct {
+	BaseResponse
+	HtmlContent string `json:"htmlContent"`
+}
+
 // InstallmentDetail represents installment detail
 type InstallmentDetail struct {
 	BinNumber         string              `json:"binNumber"`
@@ -416,6 +864,36 @@ type PaymentItemResponse struct {
 	PaymentItems []PaymentItem `json:"paymentItems"`
 }
 
+// ApprovePaymentItemRequest represents payment item approve/disapprove request
+type ApprovePaymentItemRequest struct {
+	Locale               string `json:"locale"`
+	ConversationID       string `json:"conversationId"`
+	PaymentTransactionID string `json:"paymentTransactionId"`
+}
+
+// PaymentItemApprovalResponse represents payment item approve/disapprove response
+type PaymentItemApprovalResponse struct {
+	BaseResponse
+	PaymentTransactionID string `json:"paymentTransactionId"`
+}
+
+// ApprovePaymentItemsRequest represents batch approve/disapprove request for item transactions
+type ApprovePaymentItemsRequest struct {
+	Locale           string
+	ConversationID   string
+	ItemTransactions []ItemTransaction
+}
+
+// PaymentItemApprovalResult represents the outcome of approving or disapproving a single item transaction
+type PaymentItemApprovalResult struct {
+	ItemID               string
+	PaymentTransactionID string
+	// Succeeded reports whether the approval or disapproval was applied
+	Succeeded            bool
+	Response             *PaymentItemApprovalResponse
+	Err                  error
+}
+
 // CrossBookingRequest represents cross booking request
 type CrossBookingRequest struct {
 	Locale               string `json:"locale"`
@@ -471,12 +949,47 @@ type SettlementToBalanceResponse struct {
 	Currency       string `json:"currency"`
 }
 
+// RetrieveSettlementReportRequest represents settlement report request.
+// Date is in yyyy-MM-dd HH:mm:ss format.
+type RetrieveSettlementReportRequest struct {
+	Locale         string `json:"locale"`
+	ConversationID string `json:"conversationId"`
+	Date           string `json:"date"`
+}
+
+// BouncedSettlementResponse represents bounced bank transfer report response
+type BouncedSettlementResponse struct {
+	BaseResponse
+	BouncedRows []SettlementPayoutRow `json:"bouncedRows"`
+}
+
+// PayoutCompletedResponse represents payout completed transaction report response
+type PayoutCompletedResponse struct {
+	BaseResponse
+	PayoutCompletedTransactions []SettlementPayoutRow `json:"payoutCompletedTransactions"`
+}
+
+// SettlementPayoutRow represents a single payout row in settlement reports
+type SettlementPayoutRow struct {
+	PaymentTransactionID       string `json:"paymentTransactionId"`
+	SubMerchantKey             string `json:"subMerchantKey"`
+	IBAN                       string `json:"iban" redact:"mask"`
+	ContactName                string `json:"contactName"`
+	ContactSurname             string `json:"contactSurname"`
+	LegalCompanyTitle          string `json:"legalCompanyTitle"`
+	MarketplaceSubMerchantType string `json:"marketplaceSubMerchantType"`
+	PayoutType                 string `json:"payoutType"`
+	PayoutAmount               string `json:"payoutAmount"`
+	Currency                   string `json:"currency"`
+	ConversionRate             string `json:"conversionRate"`
+}
+
 // UniversalCardStorageInitializeRequest represents universal card storage initialize request
 type UniversalCardStorageInitializeRequest struct {
 	Locale         string `json:"locale"`
 	ConversationID string `json:"conversationId"`
 	Email          string `json:"email"`
-	GsmNumber      string `json:"gsmNumber"`
+	GsmNumber      string `json:"gsmNumber" redact:"mask"`
 	CardAlias      string `json:"cardAlias"`
 	CallbackURL    string `json:"callbackUrl"`
 }
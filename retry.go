@@ -0,0 +1,128 @@
+package iyzipay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures automatic retries with exponential backoff.
+// Read operations are retried by default; mutating operations are retried
+// only when the call context is marked with WithIdempotentRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter randomizes each backoff by up to ±Jitter (0 to 1) of its value
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the retry policy used when Config.RetryPolicy is nil
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// backoff returns the delay before the given retry (1 for the first retry)
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+type idempotentRetryKey struct{}
+
+// WithIdempotentRetry marks calls made with the returned context as safe to retry.
+// Use it for mutating operations only when retrying cannot cause a duplicate effect.
+func WithIdempotentRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentRetryKey{}, true)
+}
+
+func isIdempotentRetry(ctx context.Context) bool {
+	idempotent, _ := ctx.Value(idempotentRetryKey{}).(bool)
+	return idempotent
+}
+
+// readOnlyEndpoints lists POST endpoints that only read data and are safe to retry
+var readOnlyEndpoints = map[string]bool{
+	EndpointPaymentDetail:                      true,
+	EndpointPaymentBinCheck:                    true,
+	EndpointPaymentInstallment:                 true,
+	EndpointPaymentInstallmentHTML:             true,
+	EndpointCheckoutFormAuthDetail:             true,
+	EndpointCardStorageCards:                   true,
+	EndpointBKMAuthDetail:                      true,
+	EndpointBKMAuthDetailBasic:                 true,
+	EndpointAPMRetrieve:                        true,
+	EndpointSubMerchantDetail:                  true,
+	EndpointReportingSettlementBounced:         true,
+	EndpointReportingSettlementPayoutCompleted: true,
+}
+
+// isReadOperation reports whether the request only reads data
+func isReadOperation(method, endpoint string) bool {
+	return method == http.MethodGet || readOnlyEndpoints[stripQuery(endpoint)]
+}
+
+// isRetryableError reports whether err is a transient failure worth retrying
+func isRetryableError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable()
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// shouldRetry decides whether a failed attempt is retried
+func (c *Client) shouldRetry(ctx context.Context, method, endpoint string, attempt int, err error) bool {
+	policy := c.config.RetryPolicy
+	if policy == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !isReadOperation(method, endpoint) && !isIdempotentRetry(ctx) {
+		return false
+	}
+	return isRetryableError(err)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,107 @@
+package iyzipay
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy keeps the default attempts but with millisecond backoff
+func fastRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+func TestRetryReadOperation(t *testing.T) {
+	var randomStrings []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		randomStrings = append(randomStrings, r.Header.Get(HeaderRandomString))
+		if len(randomStrings) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status": "success", "paymentId": "12345"}`))
+	})
+	client.config.RetryPolicy = fastRetryPolicy()
+
+	response, err := client.Payment.Retrieve(context.Background(), &RetrievePaymentRequest{PaymentID: "12345"})
+	if err != nil {
+		t.Fatalf("Retrieve failed: %v", err)
+	}
+
+	if response.PaymentID != "12345" {
+		t.Errorf("Expected payment ID 12345, got %s", response.PaymentID)
+	}
+
+	if len(randomStrings) != 3 {
+		t.Fatalf("Expected 3 attempts, got %d", len(randomStrings))
+	}
+
+	if randomStrings[0] == randomStrings[1] || randomStrings[1] == randomStrings[2] {
+		t.Error("Each attempt should use a new random string")
+	}
+}
+
+func TestRetryMutatingOperation(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.config.RetryPolicy = fastRetryPolicy()
+
+	if _, err := client.Payment.Create(context.Background(), &PaymentRequest{}); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected mutating operation not to be retried, got %d attempts", attempts)
+	}
+
+	attempts = 0
+	if _, err := client.Payment.Create(WithIdempotentRetry(context.Background()), &PaymentRequest{}); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts with idempotent retry, got %d", attempts)
+	}
+}
+
+func TestRetryNonRetryableError(t *testing.T) {
+	attempts := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Write([]byte(`{"status": "failure", "errorCode": "10051"}`))
+	})
+	client.config.RetryPolicy = fastRetryPolicy()
+
+	if _, err := client.BinNumber.Retrieve(context.Background(), &RetrieveBinNumberRequest{BinNumber: "552879"}); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if attempts != 1 {
+		t.Errorf("Expected decline not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, want)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package iyzipay
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 )
 
 // APITestService handles API test operations
@@ -13,7 +16,7 @@ type APITestService struct {
 // Retrieve performs API test
 func (s *APITestService) Retrieve(ctx context.Context) (*APITestResponse, error) {
 	var response APITestResponse
-	err := s.client.doRequest(ctx, http.MethodGet, EndpointAPITest, nil, &response)
+	err := s.client.doRequest(ctx, "APITest.Retrieve", http.MethodGet, EndpointAPITest, nil, &response)
 	return &response, err
 }
 
@@ -24,15 +27,22 @@ type PaymentService struct {
 
 // Create creates a new payment
 func (s *PaymentService) Create(ctx context.Context, request *PaymentRequest) (*PaymentResponse, error) {
+	key := idempotencyKey("Payment.Create", request.ConversationID, request.BasketID)
+	reconcile := s.client.reconcileByPayment(&RetrievePaymentRequest{
+		Locale:                request.Locale,
+		ConversationID:        request.ConversationID,
+		PaymentConversationID: request.ConversationID,
+	})
+
 	var response PaymentResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPaymentAuth, request, &response)
+	err := s.client.doIdempotentRequest(ctx, "Payment.Create", key, http.MethodPost, EndpointPaymentAuth, request, &response, reconcile)
 	return &response, err
 }
 
 // Retrieve retrieves payment details
 func (s *PaymentService) Retrieve(ctx context.Context, request *RetrievePaymentRequest) (*PaymentResponse, error) {
 	var response PaymentResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPaymentDetail, request, &response)
+	err := s.client.doRequest(ctx, "Payment.Retrieve", http.MethodPost, EndpointPaymentDetail, request, &response)
 	return &response, err
 }
 
@@ -43,8 +53,53 @@ type BasicPaymentService struct {
 
 // Create creates a new basic payment
 func (s *BasicPaymentService) Create(ctx context.Context, request *BasicPaymentRequest) (*PaymentResponse, error) {
+	key := idempotencyKey("BasicPayment.Create", request.ConversationID, request.PosOrderID)
+	reconcile := s.client.reconcileByPayment(&RetrievePaymentRequest{
+		Locale:                request.Locale,
+		ConversationID:        request.ConversationID,
+		PaymentConversationID: request.ConversationID,
+	})
+
+	var response PaymentResponse
+	err := s.client.doIdempotentRequest(ctx, "BasicPayment.Create", key, http.MethodPost, EndpointPaymentAuthBasic, request, &response, reconcile)
+	return &response, err
+}
+
+// PreAuthService handles pre-authorization operations
+type PreAuthService struct {
+	client *Client
+}
+
+// Create creates a pre-authorization that holds the amount on the card
+func (s *PreAuthService) Create(ctx context.Context, request *PaymentRequest) (*PaymentResponse, error) {
 	var response PaymentResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPaymentAuthBasic, request, &response)
+	err := s.client.doRequest(ctx, "PreAuth.Create", http.MethodPost, EndpointPaymentPreAuth, request, &response)
+	return &response, err
+}
+
+// CreateBasic creates a basic pre-authorization
+func (s *PreAuthService) CreateBasic(ctx context.Context, request *BasicPaymentRequest) (*PaymentResponse, error) {
+	var response PaymentResponse
+	err := s.client.doRequest(ctx, "PreAuth.CreateBasic", http.MethodPost, EndpointPaymentPreAuthBasic, request, &response)
+	return &response, err
+}
+
+// PostAuthService handles post-authorization (capture) operations
+type PostAuthService struct {
+	client *Client
+}
+
+// Create captures a pre-authorized payment
+func (s *PostAuthService) Create(ctx context.Context, request *CreatePostAuthRequest) (*PaymentResponse, error) {
+	var response PaymentResponse
+	err := s.client.doRequest(ctx, "PostAuth.Create", http.MethodPost, EndpointPaymentPostAuth, request, &response)
+	return &response, err
+}
+
+// CreateBasic captures a basic pre-authorized payment
+func (s *PostAuthService) CreateBasic(ctx context.Context, request *CreatePostAuthRequest) (*PaymentResponse, error) {
+	var response PaymentResponse
+	err := s.client.doRequest(ctx, "PostAuth.CreateBasic", http.MethodPost, EndpointPaymentPostAuthBasic, request, &response)
 	return &response, err
 }
 
@@ -56,14 +111,28 @@ type ThreedsInitializeService struct {
 // Create initializes 3DS payment
 func (s *ThreedsInitializeService) Create(ctx context.Context, request *PaymentRequest) (*ThreedsInitializeResponse, error) {
 	var response ThreedsInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPayment3DSecureInitialize, request, &response)
+	err := s.client.doRequest(ctx, "ThreedsInitialize.Create", http.MethodPost, EndpointPayment3DSecureInitialize, request, &response)
 	return &response, err
 }
 
 // CreateBasic initializes basic 3DS payment
 func (s *ThreedsInitializeService) CreateBasic(ctx context.Context, request *BasicPaymentRequest) (*ThreedsInitializeResponse, error) {
 	var response ThreedsInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPayment3DSecureInitializeBasic, request, &response)
+	err := s.client.doRequest(ctx, "ThreedsInitialize.CreateBasic", http.MethodPost, EndpointPayment3DSecureInitializeBasic, request, &response)
+	return &response, err
+}
+
+// CreatePreAuth initializes 3DS pre-authorization
+func (s *ThreedsInitializeService) CreatePreAuth(ctx context.Context, request *PaymentRequest) (*ThreedsInitializeResponse, error) {
+	var response ThreedsInitializeResponse
+	err := s.client.doRequest(ctx, "ThreedsInitialize.CreatePreAuth", http.MethodPost, EndpointPayment3DSecureInitializePreAuth, request, &response)
+	return &response, err
+}
+
+// CreatePreAuthBasic initializes basic 3DS pre-authorization
+func (s *ThreedsInitializeService) CreatePreAuthBasic(ctx context.Context, request *BasicPaymentRequest) (*ThreedsInitializeResponse, error) {
+	var response ThreedsInitializeResponse
+	err := s.client.doRequest(ctx, "ThreedsInitialize.CreatePreAuthBasic", http.MethodPost, EndpointPayment3DSecureInitializePreAuthBasic, request, &response)
 	return &response, err
 }
 
@@ -74,18 +143,31 @@ type ThreedsPaymentService struct {
 
 // Create completes 3DS payment
 func (s *ThreedsPaymentService) Create(ctx context.Context, request *ThreedsPaymentRequest) (*PaymentResponse, error) {
+	key := idempotencyKey("ThreedsPayment.Create", request.ConversationID, request.PaymentID)
+
 	var response PaymentResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPayment3DSecureAuth, request, &response)
+	err := s.client.doIdempotentRequest(ctx, "ThreedsPayment.Create", key, http.MethodPost, EndpointPayment3DSecureAuth, request, &response, s.reconcile(request))
 	return &response, err
 }
 
 // CreateBasic completes basic 3DS payment
 func (s *ThreedsPaymentService) CreateBasic(ctx context.Context, request *ThreedsPaymentRequest) (*PaymentResponse, error) {
+	key := idempotencyKey("ThreedsPayment.CreateBasic", request.ConversationID, request.PaymentID)
+
 	var response PaymentResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPayment3DSecureAuthBasic, request, &response)
+	err := s.client.doIdempotentRequest(ctx, "ThreedsPayment.CreateBasic", key, http.MethodPost, EndpointPayment3DSecureAuthBasic, request, &response, s.reconcile(request))
 	return &response, err
 }
 
+// reconcile looks up the 3DS payment by its payment ID
+func (s *ThreedsPaymentService) reconcile(request *ThreedsPaymentRequest) reconcileFunc {
+	return s.client.reconcileByPayment(&RetrievePaymentRequest{
+		Locale:         request.Locale,
+		ConversationID: request.ConversationID,
+		PaymentID:      request.PaymentID,
+	})
+}
+
 // CheckoutFormService handles checkout form operations
 type CheckoutFormService struct {
 	client *Client
@@ -94,14 +176,21 @@ type CheckoutFormService struct {
 // Initialize initializes checkout form
 func (s *CheckoutFormService) Initialize(ctx context.Context, request *CheckoutFormInitializeRequest) (*CheckoutFormInitializeResponse, error) {
 	var response CheckoutFormInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointCheckoutFormInitializeAuth, request, &response)
+	err := s.client.doRequest(ctx, "CheckoutForm.Initialize", http.MethodPost, EndpointCheckoutFormInitializeAuth, request, &response)
+	return &response, err
+}
+
+// InitializePreAuth initializes checkout form for pre-authorization
+func (s *CheckoutFormService) InitializePreAuth(ctx context.Context, request *CheckoutFormInitializeRequest) (*CheckoutFormInitializeResponse, error) {
+	var response CheckoutFormInitializeResponse
+	err := s.client.doRequest(ctx, "CheckoutForm.InitializePreAuth", http.MethodPost, EndpointCheckoutFormInitializePreAuth, request, &response)
 	return &response, err
 }
 
 // Retrieve retrieves checkout form result
 func (s *CheckoutFormService) Retrieve(ctx context.Context, request *RetrieveCheckoutFormRequest) (*CheckoutFormResponse, error) {
 	var response CheckoutFormResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointCheckoutFormAuthDetail, request, &response)
+	err := s.client.doRequest(ctx, "CheckoutForm.Retrieve", http.MethodPost, EndpointCheckoutFormAuthDetail, request, &response)
 	return &response, err
 }
 
@@ -113,21 +202,21 @@ type CardService struct {
 // Create creates/stores a card
 func (s *CardService) Create(ctx context.Context, request *CreateCardRequest) (*CardResponse, error) {
 	var response CardResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointCardStorageCard, request, &response)
+	err := s.client.doRequest(ctx, "Card.Create", http.MethodPost, EndpointCardStorageCard, request, &response)
 	return &response, err
 }
 
 // Delete deletes a stored card
 func (s *CardService) Delete(ctx context.Context, request *DeleteCardRequest) (*BaseResponse, error) {
 	var response BaseResponse
-	err := s.client.doRequest(ctx, http.MethodDelete, EndpointCardStorageCard, request, &response)
+	err := s.client.doRequest(ctx, "Card.Delete", http.MethodDelete, EndpointCardStorageCard, request, &response)
 	return &response, err
 }
 
 // List retrieves list of stored cards
 func (s *CardService) List(ctx context.Context, request *RetrieveCardListRequest) (*CardListResponse, error) {
 	var response CardListResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointCardStorageCards, request, &response)
+	err := s.client.doRequest(ctx, "Card.List", http.MethodPost, EndpointCardStorageCards, request, &response)
 	return &response, err
 }
 
@@ -138,8 +227,17 @@ type RefundService struct {
 
 // Create creates a refund
 func (s *RefundService) Create(ctx context.Context, request *RefundRequest) (*RefundResponse, error) {
+	key := idempotencyKey("Refund.Create", request.ConversationID, request.PaymentTransactionID)
+
 	var response RefundResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPaymentRefund, request, &response)
+	err := s.client.doIdempotentRequest(ctx, "Refund.Create", key, http.MethodPost, EndpointPaymentRefund, request, &response, nil)
+	return &response, err
+}
+
+// CreateChargedFromMerchant creates a refund covered by the merchant balance instead of the sub merchant
+func (s *RefundService) CreateChargedFromMerchant(ctx context.Context, request *RefundChargedFromMerchantRequest) (*RefundChargedFromMerchantResponse, error) {
+	var response RefundChargedFromMerchantResponse
+	err := s.client.doRequest(ctx, "Refund.CreateChargedFromMerchant", http.MethodPost, EndpointRefundChargedFromMerchant, request, &response)
 	return &response, err
 }
 
@@ -150,8 +248,10 @@ type CancelService struct {
 
 // Create cancels a payment
 func (s *CancelService) Create(ctx context.Context, request *CancelRequest) (*CancelResponse, error) {
+	key := idempotencyKey("Cancel.Create", request.ConversationID, request.PaymentID)
+
 	var response CancelResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPaymentCancel, request, &response)
+	err := s.client.doIdempotentRequest(ctx, "Cancel.Create", key, http.MethodPost, EndpointPaymentCancel, request, &response, nil)
 	return &response, err
 }
 
@@ -163,21 +263,21 @@ type SubMerchantService struct {
 // Create creates a sub merchant
 func (s *SubMerchantService) Create(ctx context.Context, request *CreateSubMerchantRequest) (*SubMerchantResponse, error) {
 	var response SubMerchantResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointSubMerchant, request, &response)
+	err := s.client.doRequest(ctx, "SubMerchant.Create", http.MethodPost, EndpointSubMerchant, request, &response)
 	return &response, err
 }
 
 // Update updates a sub merchant
 func (s *SubMerchantService) Update(ctx context.Context, request *UpdateSubMerchantRequest) (*SubMerchantResponse, error) {
 	var response SubMerchantResponse
-	err := s.client.doRequest(ctx, http.MethodPut, EndpointSubMerchant, request, &response)
+	err := s.client.doRequest(ctx, "SubMerchant.Update", http.MethodPut, EndpointSubMerchant, request, &response)
 	return &response, err
 }
 
 // Retrieve retrieves sub merchant details
 func (s *SubMerchantService) Retrieve(ctx context.Context, request *RetrieveSubMerchantRequest) (*SubMerchantResponse, error) {
 	var response SubMerchantResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointSubMerchantDetail, request, &response)
+	err := s.client.doRequest(ctx, "SubMerchant.Retrieve", http.MethodPost, EndpointSubMerchantDetail, request, &response)
 	return &response, err
 }
 
@@ -189,21 +289,28 @@ type BKMService struct {
 // Initialize initializes BKM payment
 func (s *BKMService) Initialize(ctx context.Context, request *BKMInitializeRequest) (*BKMInitializeResponse, error) {
 	var response BKMInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointBKMInitialize, request, &response)
+	err := s.client.doRequest(ctx, "BKM.Initialize", http.MethodPost, EndpointBKMInitialize, request, &response)
 	return &response, err
 }
 
 // InitializeBasic initializes basic BKM payment
 func (s *BKMService) InitializeBasic(ctx context.Context, request *BasicBKMInitializeRequest) (*BKMInitializeResponse, error) {
 	var response BKMInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointBKMInitializeBasic, request, &response)
+	err := s.client.doRequest(ctx, "BKM.InitializeBasic", http.MethodPost, EndpointBKMInitializeBasic, request, &response)
 	return &response, err
 }
 
 // Retrieve retrieves BKM payment result
 func (s *BKMService) Retrieve(ctx context.Context, request *RetrieveBKMRequest) (*BKMResponse, error) {
 	var response BKMResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointBKMAuthDetail, request, &response)
+	err := s.client.doRequest(ctx, "BKM.Retrieve", http.MethodPost, EndpointBKMAuthDetail, request, &response)
+	return &response, err
+}
+
+// RetrieveBasic retrieves basic BKM payment result
+func (s *BKMService) RetrieveBasic(ctx context.Context, request *RetrieveBKMRequest) (*BasicBKMResponse, error) {
+	var response BasicBKMResponse
+	err := s.client.doRequest(ctx, "BKM.RetrieveBasic", http.MethodPost, EndpointBKMAuthDetailBasic, request, &response)
 	return &response, err
 }
 
@@ -215,14 +322,33 @@ type APMService struct {
 // Initialize initializes APM payment
 func (s *APMService) Initialize(ctx context.Context, request *APMRequest) (*APMInitializeResponse, error) {
 	var response APMInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointAPMInitialize, request, &response)
+	err := s.client.doRequest(ctx, "APM.Initialize", http.MethodPost, EndpointAPMInitialize, request, &response)
 	return &response, err
 }
 
 // Retrieve retrieves APM payment result
 func (s *APMService) Retrieve(ctx context.Context, request *RetrieveAPMRequest) (*APMResponse, error) {
 	var response APMResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointAPMRetrieve, request, &response)
+	err := s.client.doRequest(ctx, "APM.Retrieve", http.MethodPost, EndpointAPMRetrieve, request, &response)
+	return &response, err
+}
+
+// PeccoService handles Pecco payment operations
+type PeccoService struct {
+	client *Client
+}
+
+// Initialize initializes Pecco payment
+func (s *PeccoService) Initialize(ctx context.Context, request *CreatePeccoInitializeRequest) (*PeccoInitializeResponse, error) {
+	var response PeccoInitializeResponse
+	err := s.client.doRequest(ctx, "Pecco.Initialize", http.MethodPost, EndpointPeccoInitialize, request, &response)
+	return &response, err
+}
+
+// Create completes Pecco payment with the token returned by Initialize
+func (s *PeccoService) Create(ctx context.Context, request *CreatePeccoPaymentRequest) (*PaymentResponse, error) {
+	var response PaymentResponse
+	err := s.client.doRequest(ctx, "Pecco.Create", http.MethodPost, EndpointPeccoAuth, request, &response)
 	return &response, err
 }
 
@@ -234,7 +360,310 @@ type SubscriptionService struct {
 // Initialize initializes a subscription
 func (s *SubscriptionService) Initialize(ctx context.Context, request *CreateSubscriptionInitRequest) (*SubscriptionInitializeResponse, error) {
 	var response SubscriptionInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointSubscriptionInitialize, request, &response)
+	err := s.client.doRequest(ctx, "Subscription.Initialize", http.MethodPost, EndpointSubscriptionInitialize, request, &response)
+	return &response, err
+}
+
+// InitializeWithCustomer initializes a subscription for an existing subscription customer
+func (s *SubscriptionService) InitializeWithCustomer(ctx context.Context, request *CreateSubscriptionInitWithCustomerRequest) (*SubscriptionInitializeResponse, error) {
+	var response SubscriptionInitializeResponse
+	err := s.client.doRequest(ctx, "Subscription.InitializeWithCustomer", http.MethodPost, EndpointSubscriptionInitializeWithCustomer, request, &response)
+	return &response, err
+}
+
+// Cancel cancels a subscription
+func (s *SubscriptionService) Cancel(ctx context.Context, request *CancelSubscriptionRequest) (*BaseResponse, error) {
+	var response BaseResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.Cancel", http.MethodPost, EndpointSubscriptionCancel, map[string]string{
+		"subscriptionReferenceCode": request.SubscriptionReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// Activate activates a pending subscription
+func (s *SubscriptionService) Activate(ctx context.Context, request *ActivateSubscriptionRequest) (*BaseResponse, error) {
+	var response BaseResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.Activate", http.MethodPost, EndpointSubscriptionActivate, map[string]string{
+		"subscriptionReferenceCode": request.SubscriptionReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// Upgrade moves a subscription to another pricing plan
+func (s *SubscriptionService) Upgrade(ctx context.Context, request *UpgradeSubscriptionRequest) (*SubscriptionResponse, error) {
+	var response SubscriptionResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.Upgrade", http.MethodPost, EndpointSubscriptionUpgrade, map[string]string{
+		"subscriptionReferenceCode": request.SubscriptionReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// Retrieve retrieves a subscription with its order history
+func (s *SubscriptionService) Retrieve(ctx context.Context, request *RetrieveSubscriptionRequest) (*SubscriptionListResponse, error) {
+	var response SubscriptionListResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.Retrieve", http.MethodGet, EndpointSubscriptionRetrieve, map[string]string{
+		"subscriptionReferenceCode": request.SubscriptionReferenceCode,
+	}, nil, &response)
+	return &response, err
+}
+
+// RetryPayment retries the payment of a failed subscription order
+func (s *SubscriptionService) RetryPayment(ctx context.Context, request *RetrySubscriptionPaymentRequest) (*BaseResponse, error) {
+	var response BaseResponse
+	err := s.client.doRequest(ctx, "Subscription.RetryPayment", http.MethodPost, EndpointSubscriptionPaymentRetry, request, &response)
+	return &response, err
+}
+
+// Search searches subscriptions by the filters set in request
+func (s *SubscriptionService) Search(ctx context.Context, request *SearchSubscriptionRequest) (*SubscriptionListResponse, error) {
+	query := url.Values{}
+	filters := map[string]string{
+		"subscriptionReferenceCode": request.SubscriptionReferenceCode,
+		"customerReferenceCode":     request.CustomerReferenceCode,
+		"pricingPlanReferenceCode":  request.PricingPlanReferenceCode,
+		"parentReferenceCode":       request.ParentReferenceCode,
+		"subscriptionStatus":        request.SubscriptionStatus,
+		"startDate":                 request.StartDate,
+		"endDate":                   request.EndDate,
+	}
+	for key, value := range filters {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if request.Page > 0 {
+		query.Set("page", strconv.Itoa(request.Page))
+	}
+	if request.Count > 0 {
+		query.Set("count", strconv.Itoa(request.Count))
+	}
+
+	endpoint := EndpointSubscriptionSearch
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var response SubscriptionListResponse
+	err := s.client.doRequest(ctx, "Subscription.Search", http.MethodGet, endpoint, nil, &response)
+	return &response, err
+}
+
+// Iterate returns an iterator over every subscription matching request, starting at request.Page
+func (s *SubscriptionService) Iterate(request *SearchSubscriptionRequest) *SubscriptionIterator {
+	iterator := &SubscriptionIterator{
+		service: s,
+		request: *request,
+	}
+	if iterator.request.Page < 1 {
+		iterator.request.Page = 1
+	}
+	return iterator
+}
+
+// SubscriptionIterator walks through subscription search results page by page
+type SubscriptionIterator struct {
+	service *SubscriptionService
+	request SearchSubscriptionRequest
+	items   []SubscriptionData
+	index   int
+	current *SubscriptionData
+	done    bool
+	err     error
+}
+
+// Next advances to the next subscription, fetching the next page when needed
+func (it *SubscriptionIterator) Next(ctx context.Context) bool {
+	for it.index >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		response, err := it.service.Search(ctx, &it.request)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.items = response.Data.Items
+		it.index = 0
+		if len(it.items) == 0 || response.Data.PageCount <= it.request.Page {
+			it.done = true
+		}
+		it.request.Page++
+	}
+
+	it.current = &it.items[it.index]
+	it.index++
+	return true
+}
+
+// Subscription returns the current subscription
+func (it *SubscriptionIterator) Subscription() *SubscriptionData {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SubscriptionIterator) Err() error {
+	return it.err
+}
+
+// InitializeCheckoutForm initializes the hosted subscription checkout form
+func (s *SubscriptionService) InitializeCheckoutForm(ctx context.Context, request *CreateSubscriptionCheckoutFormRequest) (*SubscriptionCheckoutFormInitializeResponse, error) {
+	var response SubscriptionCheckoutFormInitializeResponse
+	err := s.client.doRequest(ctx, "Subscription.InitializeCheckoutForm", http.MethodPost, EndpointSubscriptionCheckoutFormInitialize, request, &response)
+	return &response, err
+}
+
+// RetrieveCheckoutForm retrieves the subscription created through the hosted checkout form
+func (s *SubscriptionService) RetrieveCheckoutForm(ctx context.Context, request *RetrieveSubscriptionCheckoutFormRequest) (*SubscriptionResponse, error) {
+	var response SubscriptionResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.RetrieveCheckoutForm", http.MethodGet, EndpointSubscriptionCheckoutFormRetrieve, map[string]string{
+		"checkoutFormToken": request.Token,
+	}, nil, &response)
+	return &response, err
+}
+
+// InitializeCardUpdate initializes the hosted card update form for a subscription customer
+func (s *SubscriptionService) InitializeCardUpdate(ctx context.Context, request *CreateSubscriptionCardUpdateRequest) (*SubscriptionCheckoutFormInitializeResponse, error) {
+	var response SubscriptionCheckoutFormInitializeResponse
+	if request.CustomerReferenceCode == "" {
+		err := s.client.reject(ctx, "Subscription.InitializeCardUpdate", http.MethodPost, EndpointSubscriptionCardUpdateInitialize, request, &response, fmt.Errorf("customerReferenceCode cannot be empty"))
+		return &response, err
+	}
+
+	err := s.client.doRequest(ctx, "Subscription.InitializeCardUpdate", http.MethodPost, EndpointSubscriptionCardUpdateInitialize, request, &response)
+	return &response, err
+}
+
+// InitializeCardUpdateWithSubscription initializes the hosted card update form for a single subscription
+func (s *SubscriptionService) InitializeCardUpdateWithSubscription(ctx context.Context, request *CreateSubscriptionCardUpdateRequest) (*SubscriptionCheckoutFormInitializeResponse, error) {
+	var response SubscriptionCheckoutFormInitializeResponse
+	if request.SubscriptionReferenceCode == "" {
+		err := s.client.reject(ctx, "Subscription.InitializeCardUpdateWithSubscription", http.MethodPost, EndpointSubscriptionCardUpdateWithSubscription, request, &response, fmt.Errorf("subscriptionReferenceCode cannot be empty"))
+		return &response, err
+	}
+
+	err := s.client.doRequest(ctx, "Subscription.InitializeCardUpdateWithSubscription", http.MethodPost, EndpointSubscriptionCardUpdateWithSubscription, request, &response)
+	return &response, err
+}
+
+// CreateProduct creates a subscription product
+func (s *SubscriptionService) CreateProduct(ctx context.Context, request *SubscriptionProduct) (*SubscriptionProductResponse, error) {
+	var response SubscriptionProductResponse
+	err := s.client.doRequest(ctx, "Subscription.CreateProduct", http.MethodPost, EndpointSubscriptionProducts, request, &response)
+	return &response, err
+}
+
+// UpdateProduct updates a subscription product
+func (s *SubscriptionService) UpdateProduct(ctx context.Context, request *UpdateSubscriptionProductRequest) (*SubscriptionProductResponse, error) {
+	var response SubscriptionProductResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.UpdateProduct", http.MethodPost, EndpointSubscriptionProduct, map[string]string{
+		"productReferenceCode": request.ProductReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// RetrieveProduct retrieves a subscription product
+func (s *SubscriptionService) RetrieveProduct(ctx context.Context, request *RetrieveSubscriptionProductRequest) (*SubscriptionProductResponse, error) {
+	var response SubscriptionProductResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.RetrieveProduct", http.MethodGet, EndpointSubscriptionProduct, map[string]string{
+		"productReferenceCode": request.ProductReferenceCode,
+	}, nil, &response)
+	return &response, err
+}
+
+// DeleteProduct deletes a subscription product
+func (s *SubscriptionService) DeleteProduct(ctx context.Context, request *DeleteSubscriptionProductRequest) (*BaseResponse, error) {
+	var response BaseResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.DeleteProduct", http.MethodDelete, EndpointSubscriptionProduct, map[string]string{
+		"productReferenceCode": request.ProductReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// ListProducts retrieves a page of subscription products
+func (s *SubscriptionService) ListProducts(ctx context.Context, request *Pagination) (*SubscriptionProductListResponse, error) {
+	var response SubscriptionProductListResponse
+	err := s.client.doRequest(ctx, "Subscription.ListProducts", http.MethodGet, withPagination(EndpointSubscriptionProducts, request), nil, &response)
+	return &response, err
+}
+
+// CreatePricingPlan creates a pricing plan under request.ProductReferenceCode
+func (s *SubscriptionService) CreatePricingPlan(ctx context.Context, request *SubscriptionPricingPlan) (*SubscriptionPricingPlanResponse, error) {
+	var response SubscriptionPricingPlanResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.CreatePricingPlan", http.MethodPost, EndpointSubscriptionPricingPlans, map[string]string{
+		"productReferenceCode": request.ProductReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// UpdatePricingPlan updates a subscription pricing plan
+func (s *SubscriptionService) UpdatePricingPlan(ctx context.Context, request *UpdateSubscriptionPricingPlanRequest) (*SubscriptionPricingPlanResponse, error) {
+	var response SubscriptionPricingPlanResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.UpdatePricingPlan", http.MethodPost, EndpointSubscriptionPricingPlan, map[string]string{
+		"pricingPlanReferenceCode": request.PricingPlanReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// RetrievePricingPlan retrieves a subscription pricing plan
+func (s *SubscriptionService) RetrievePricingPlan(ctx context.Context, request *RetrieveSubscriptionPricingPlanRequest) (*SubscriptionPricingPlanResponse, error) {
+	var response SubscriptionPricingPlanResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.RetrievePricingPlan", http.MethodGet, EndpointSubscriptionPricingPlanRetrieve, map[string]string{
+		"pricingPlanReferenceCode": request.PricingPlanReferenceCode,
+	}, nil, &response)
+	return &response, err
+}
+
+// DeletePricingPlan deletes a subscription pricing plan
+func (s *SubscriptionService) DeletePricingPlan(ctx context.Context, request *DeleteSubscriptionPricingPlanRequest) (*BaseResponse, error) {
+	var response BaseResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.DeletePricingPlan", http.MethodDelete, EndpointSubscriptionPricingPlan, map[string]string{
+		"pricingPlanReferenceCode": request.PricingPlanReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// ListPricingPlans retrieves a page of pricing plans for a subscription product
+func (s *SubscriptionService) ListPricingPlans(ctx context.Context, request *RetrieveSubscriptionPricingPlansRequest) (*SubscriptionPricingPlanListResponse, error) {
+	pagination := &Pagination{Page: request.Page, Count: request.Count}
+
+	var response SubscriptionPricingPlanListResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.ListPricingPlans", http.MethodGet, withPagination(EndpointSubscriptionPricingPlans, pagination), map[string]string{
+		"productReferenceCode": request.ProductReferenceCode,
+	}, nil, &response)
+	return &response, err
+}
+
+// CreateCustomer creates a subscription customer
+func (s *SubscriptionService) CreateCustomer(ctx context.Context, request *CreateSubscriptionCustomerRequest) (*SubscriptionCustomerResponse, error) {
+	var response SubscriptionCustomerResponse
+	err := s.client.doRequest(ctx, "Subscription.CreateCustomer", http.MethodPost, EndpointSubscriptionCustomers, request, &response)
+	return &response, err
+}
+
+// UpdateCustomer updates a subscription customer
+func (s *SubscriptionService) UpdateCustomer(ctx context.Context, request *UpdateSubscriptionCustomerRequest) (*SubscriptionCustomerResponse, error) {
+	var response SubscriptionCustomerResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.UpdateCustomer", http.MethodPost, EndpointSubscriptionCustomer, map[string]string{
+		"customerReferenceCode": request.CustomerReferenceCode,
+	}, request, &response)
+	return &response, err
+}
+
+// RetrieveCustomer retrieves a subscription customer
+func (s *SubscriptionService) RetrieveCustomer(ctx context.Context, request *RetrieveSubscriptionCustomerRequest) (*SubscriptionCustomerResponse, error) {
+	var response SubscriptionCustomerResponse
+	err := s.client.doRequestWithParams(ctx, "Subscription.RetrieveCustomer", http.MethodGet, EndpointSubscriptionCustomer, map[string]string{
+		"customerReferenceCode": request.CustomerReferenceCode,
+	}, nil, &response)
+	return &response, err
+}
+
+// ListCustomers retrieves a page of subscription customers
+func (s *SubscriptionService) ListCustomers(ctx context.Context, request *Pagination) (*SubscriptionCustomerListResponse, error) {
+	var response SubscriptionCustomerListResponse
+	err := s.client.doRequest(ctx, "Subscription.ListCustomers", http.MethodGet, withPagination(EndpointSubscriptionCustomers, request), nil, &response)
 	return &response, err
 }
 
@@ -246,7 +675,14 @@ type InstallmentInfoService struct {
 // Retrieve retrieves installment information
 func (s *InstallmentInfoService) Retrieve(ctx context.Context, request *RetrieveInstallmentInfoRequest) (*InstallmentInfoResponse, error) {
 	var response InstallmentInfoResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPaymentInstallment, request, &response)
+	err := s.client.doRequest(ctx, "InstallmentInfo.Retrieve", http.MethodPost, EndpointPaymentInstallment, request, &response)
+	return &response, err
+}
+
+// RetrieveHTML retrieves iyzico's horizontal installment table as HTML
+func (s *InstallmentInfoService) RetrieveHTML(ctx context.Context, request *RetrieveInstallmentInfoRequest) (*InstallmentHTMLResponse, error) {
+	var response InstallmentHTMLResponse
+	err := s.client.doRequest(ctx, "InstallmentInfo.RetrieveHTML", http.MethodPost, EndpointPaymentInstallmentHTML, request, &response)
 	return &response, err
 }
 
@@ -258,7 +694,7 @@ type BinNumberService struct {
 // Retrieve retrieves BIN number information
 func (s *BinNumberService) Retrieve(ctx context.Context, request *RetrieveBinNumberRequest) (*BinNumberResponse, error) {
 	var response BinNumberResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointPaymentBinCheck, request, &response)
+	err := s.client.doRequest(ctx, "BinNumber.Retrieve", http.MethodPost, EndpointPaymentBinCheck, request, &response)
 	return &response, err
 }
 
@@ -270,10 +706,74 @@ type PaymentItemService struct {
 // Update updates payment item
 func (s *PaymentItemService) Update(ctx context.Context, request *UpdatePaymentItemRequest) (*PaymentItemResponse, error) {
 	var response PaymentItemResponse
-	err := s.client.doRequest(ctx, http.MethodPut, EndpointPaymentItem, request, &response)
+	err := s.client.doRequest(ctx, "PaymentItem.Update", http.MethodPut, EndpointPaymentItem, request, &response)
+	return &response, err
+}
+
+// Approve approves an item transaction so that it is paid out to the sub merchant
+func (s *PaymentItemService) Approve(ctx context.Context, request *ApprovePaymentItemRequest) (*PaymentItemApprovalResponse, error) {
+	var response PaymentItemApprovalResponse
+	err := s.client.doRequest(ctx, "PaymentItem.Approve", http.MethodPost, EndpointPaymentItemApprove, request, &response)
 	return &response, err
 }
 
+// Disapprove withdraws the approval of an item transaction
+func (s *PaymentItemService) Disapprove(ctx context.Context, request *ApprovePaymentItemRequest) (*PaymentItemApprovalResponse, error) {
+	var response PaymentItemApprovalResponse
+	err := s.client.doRequest(ctx, "PaymentItem.Disapprove", http.MethodPost, EndpointPaymentItemDisapprove, request, &response)
+	return &response, err
+}
+
+// ApproveAll approves every item transaction in request and reports a result for each item
+func (s *PaymentItemService) ApproveAll(ctx context.Context, request *ApprovePaymentItemsRequest) ([]PaymentItemApprovalResult, error) {
+	return s.batch(ctx, request, true)
+}
+
+// DisapproveAll disapproves every item transaction in request and reports a result for each item
+func (s *PaymentItemService) DisapproveAll(ctx context.Context, request *ApprovePaymentItemsRequest) ([]PaymentItemApprovalResult, error) {
+	return s.batch(ctx, request, false)
+}
+
+// batch approves or disapproves item transactions one by one.
+// The returned error is non-nil if any of the items failed.
+func (s *PaymentItemService) batch(ctx context.Context, request *ApprovePaymentItemsRequest, approve bool) ([]PaymentItemApprovalResult, error) {
+	operation := s.Disapprove
+	if approve {
+		operation = s.Approve
+	}
+
+	results := make([]PaymentItemApprovalResult, 0, len(request.ItemTransactions))
+	failed := 0
+	for _, item := range request.ItemTransactions {
+		result := PaymentItemApprovalResult{
+			ItemID:               item.ItemID,
+			PaymentTransactionID: item.PaymentTransactionID,
+		}
+
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+		} else {
+			result.Response, result.Err = operation(ctx, &ApprovePaymentItemRequest{
+				Locale:               request.Locale,
+				ConversationID:       request.ConversationID,
+				PaymentTransactionID: item.PaymentTransactionID,
+			})
+		}
+
+		if result.Err != nil {
+			failed++
+		} else {
+			result.Succeeded = true
+		}
+		results = append(results, result)
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d item transactions failed", failed, len(results))
+	}
+	return results, nil
+}
+
 // CrossBookingService handles cross booking operations
 type CrossBookingService struct {
 	client *Client
@@ -282,14 +782,14 @@ type CrossBookingService struct {
 // Send sends cross booking
 func (s *CrossBookingService) Send(ctx context.Context, request *CrossBookingRequest) (*CrossBookingResponse, error) {
 	var response CrossBookingResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointCrossBookingSend, request, &response)
+	err := s.client.doRequest(ctx, "CrossBooking.Send", http.MethodPost, EndpointCrossBookingSend, request, &response)
 	return &response, err
 }
 
 // Receive receives cross booking
 func (s *CrossBookingService) Receive(ctx context.Context, request *CrossBookingRequest) (*CrossBookingResponse, error) {
 	var response CrossBookingResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointCrossBookingReceive, request, &response)
+	err := s.client.doRequest(ctx, "CrossBooking.Receive", http.MethodPost, EndpointCrossBookingReceive, request, &response)
 	return &response, err
 }
 
@@ -301,7 +801,7 @@ type RefundToBalanceService struct {
 // Create creates refund to balance
 func (s *RefundToBalanceService) Create(ctx context.Context, request *RefundToBalanceRequest) (*RefundToBalanceResponse, error) {
 	var response RefundToBalanceResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointRefundToBalance, request, &response)
+	err := s.client.doRequest(ctx, "RefundToBalance.Create", http.MethodPost, EndpointRefundToBalance, request, &response)
 	return &response, err
 }
 
@@ -313,7 +813,26 @@ type SettlementToBalanceService struct {
 // Create creates settlement to balance
 func (s *SettlementToBalanceService) Create(ctx context.Context, request *SettlementToBalanceRequest) (*SettlementToBalanceResponse, error) {
 	var response SettlementToBalanceResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointSettlementToBalance, request, &response)
+	err := s.client.doRequest(ctx, "SettlementToBalance.Create", http.MethodPost, EndpointSettlementToBalance, request, &response)
+	return &response, err
+}
+
+// ReportingService handles settlement reporting operations
+type ReportingService struct {
+	client *Client
+}
+
+// RetrieveBounced retrieves payouts bounced by the bank on the given date
+func (s *ReportingService) RetrieveBounced(ctx context.Context, request *RetrieveSettlementReportRequest) (*BouncedSettlementResponse, error) {
+	var response BouncedSettlementResponse
+	err := s.client.doRequest(ctx, "Reporting.RetrieveBounced", http.MethodPost, EndpointReportingSettlementBounced, request, &response)
+	return &response, err
+}
+
+// RetrievePayoutCompleted retrieves payouts completed on the given date
+func (s *ReportingService) RetrievePayoutCompleted(ctx context.Context, request *RetrieveSettlementReportRequest) (*PayoutCompletedResponse, error) {
+	var response PayoutCompletedResponse
+	err := s.client.doRequest(ctx, "Reporting.RetrievePayoutCompleted", http.MethodPost, EndpointReportingSettlementPayoutCompleted, request, &response)
 	return &response, err
 }
 
@@ -325,6 +844,6 @@ type UniversalCardStorageService struct {
 // Initialize initializes universal card storage
 func (s *UniversalCardStorageService) Initialize(ctx context.Context, request *UniversalCardStorageInitializeRequest) (*UniversalCardStorageInitializeResponse, error) {
 	var response UniversalCardStorageInitializeResponse
-	err := s.client.doRequest(ctx, http.MethodPost, EndpointUniversalCardStorageInitialize, request, &response)
+	err := s.client.doRequest(ctx, "UniversalCardStorage.Initialize", http.MethodPost, EndpointUniversalCardStorageInitialize, request, &response)
 	return &response, err
 }
\ No newline at end of file
@@ -0,0 +1,719 @@
+package iyzipay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestClient starts a test server with the given handler and returns a client pointing at it
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return NewClient(&Config{
+		APIKey:    "test-api-key",
+		SecretKey: "test-secret-key",
+		BaseURL:   server.URL,
+	})
+}
+
+func TestPostAuthCreate(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointPaymentPostAuth {
+			t.Errorf("Expected path %s, got %s", EndpointPaymentPostAuth, r.URL.Path)
+		}
+
+		var request CreatePostAuthRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+
+		if request.PaymentID != "12345" {
+			t.Errorf("Expected payment ID 12345, got %s", request.PaymentID)
+		}
+
+		if request.PaidPrice != "0.8" {
+			t.Errorf("Expected paid price 0.8, got %s", request.PaidPrice)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":    "success",
+			"paymentId": request.PaymentID,
+			"paidPrice": 0.8,
+			"phase":     PaymentPhasePostAuth,
+		})
+	})
+
+	response, err := client.PostAuth.Create(context.Background(), &CreatePostAuthRequest{
+		Locale:         LocaleTR,
+		ConversationID: "123456789",
+		PaymentID:      "12345",
+		PaidPrice:      "0.8",
+		IP:             "85.34.78.112",
+		Currency:       CurrencyTRY,
+	})
+	if err != nil {
+		t.Fatalf("Post auth failed: %v", err)
+	}
+
+	if response.Phase != PaymentPhasePostAuth {
+		t.Errorf("Expected phase %s, got %s", PaymentPhasePostAuth, response.Phase)
+	}
+
+	if response.PaidPrice != "0.8" {
+		t.Errorf("Expected paid price 0.8, got %s", response.PaidPrice)
+	}
+}
+
+func TestThreedsInitializeCreatePreAuth(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointPayment3DSecureInitializePreAuth {
+			t.Errorf("Expected path %s, got %s", EndpointPayment3DSecureInitializePreAuth, r.URL.Path)
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":             "success",
+			"threeDSHtmlContent": "PGh0bWw+",
+		})
+	})
+
+	response, err := client.ThreedsInitialize.CreatePreAuth(context.Background(), &PaymentRequest{
+		Locale:         LocaleTR,
+		ConversationID: "123456789",
+		Price:          "1.0",
+		PaidPrice:      "1.0",
+		CallbackURL:    "https://www.merchant.com/callback",
+	})
+	if err != nil {
+		t.Fatalf("3DS pre auth initialize failed: %v", err)
+	}
+
+	if response.ThreedsFormData != "PGh0bWw+" {
+		t.Errorf("Expected 3DS form data PGh0bWw+, got %s", response.ThreedsFormData)
+	}
+}
+
+func TestCheckoutFormPreAuth(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EndpointCheckoutFormInitializePreAuth:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status": "success",
+				"token":  "checkout-token",
+			})
+		case EndpointCheckoutFormAuthDetail:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"status":        "success",
+				"token":         "checkout-token",
+				"paymentStatus": "SUCCESS",
+				"phase":         PaymentPhasePreAuth,
+			})
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	initResponse, err := client.CheckoutForm.InitializePreAuth(ctx, &CheckoutFormInitializeRequest{
+		Locale:         LocaleTR,
+		ConversationID: "123456789",
+		Price:          "1.0",
+		PaidPrice:      "1.0",
+	})
+	if err != nil {
+		t.Fatalf("Checkout form pre auth initialize failed: %v", err)
+	}
+
+	response, err := client.CheckoutForm.Retrieve(ctx, &RetrieveCheckoutFormRequest{
+		Locale: LocaleTR,
+		Token:  initResponse.Token,
+	})
+	if err != nil {
+		t.Fatalf("Checkout form retrieve failed: %v", err)
+	}
+
+	if !response.IsPreAuth() {
+		t.Errorf("Expected pre auth result, got phase %s", response.Phase)
+	}
+}
+
+func TestSubscriptionListProducts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET method, got %s", r.Method)
+		}
+
+		if r.URL.Path != EndpointSubscriptionProducts {
+			t.Errorf("Expected path %s, got %s", EndpointSubscriptionProducts, r.URL.Path)
+		}
+
+		if r.URL.Query().Get("page") != "1" || r.URL.Query().Get("count") != "10" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+
+		w.Write([]byte(`{
+			"status": "success",
+			"data": {
+				"totalCount": 1,
+				"currentPage": 1,
+				"pageCount": 1,
+				"items": [{
+					"referenceCode": "product-ref",
+					"name": "Premium",
+					"status": "ACTIVE",
+					"pricingPlans": [{"referenceCode": "plan-ref", "price": 49.9, "paymentInterval": "MONTHLY"}]
+				}]
+			}
+		}`))
+	})
+
+	response, err := client.Subscription.ListProducts(context.Background(), &Pagination{Page: 1, Count: 10})
+	if err != nil {
+		t.Fatalf("List products failed: %v", err)
+	}
+
+	if len(response.Data.Items) != 1 {
+		t.Fatalf("Expected 1 product, got %d", len(response.Data.Items))
+	}
+
+	product := response.Data.Items[0]
+	if product.ReferenceCode != "product-ref" {
+		t.Errorf("Expected reference code product-ref, got %s", product.ReferenceCode)
+	}
+
+	if len(product.PricingPlans) != 1 || product.PricingPlans[0].Price != "49.9" {
+		t.Errorf("Unexpected pricing plans %+v", product.PricingPlans)
+	}
+}
+
+func TestSubscriptionRetrieveProductRequiresReferenceCode(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Request should not be sent")
+	})
+
+	response, err := client.Subscription.RetrieveProduct(context.Background(), &RetrieveSubscriptionProductRequest{})
+	if err == nil {
+		t.Error("Expected error for empty product reference code")
+	}
+
+	if response == nil {
+		t.Error("Expected a non-nil response alongside the error")
+	}
+}
+
+func TestSubscriptionCreatePricingPlan(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/v2/subscription/products/product-ref/pricing-plans"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+
+		if _, ok := body["productReferenceCode"]; ok {
+			t.Error("Product reference code should only be sent in the path")
+		}
+
+		w.Write([]byte(`{"status": "success", "data": {"referenceCode": "plan-ref", "productReferenceCode": "product-ref", "trialPeriodDays": 7}}`))
+	})
+
+	response, err := client.Subscription.CreatePricingPlan(context.Background(), &SubscriptionPricingPlan{
+		Locale:               LocaleTR,
+		ConversationID:       "123456789",
+		Name:                 "Monthly",
+		Price:                "49.9",
+		CurrencyCode:         CurrencyTRY,
+		PaymentInterval:      SubscriptionPricingPlanIntervalMonthly,
+		PaymentIntervalCount: 1,
+		TrialPeriodDays:      7,
+		PlanPaymentType:      PlanPaymentTypeRecurring,
+		ProductReferenceCode: "product-ref",
+	})
+	if err != nil {
+		t.Fatalf("Create pricing plan failed: %v", err)
+	}
+
+	if response.Data.ReferenceCode != "plan-ref" {
+		t.Errorf("Expected reference code plan-ref, got %s", response.Data.ReferenceCode)
+	}
+
+	if response.Data.TrialPeriodDays != 7 {
+		t.Errorf("Expected 7 trial days, got %d", response.Data.TrialPeriodDays)
+	}
+}
+
+func TestSubscriptionRetrieveCustomer(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/v2/subscription/customers/customer-ref"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+
+		w.Write([]byte(`{
+			"status": "success",
+			"data": {
+				"referenceCode": "customer-ref",
+				"name": "John",
+				"surname": "Doe",
+				"billingAddress": {"city": "Istanbul", "district": "Kadikoy"}
+			}
+		}`))
+	})
+
+	response, err := client.Subscription.RetrieveCustomer(context.Background(), &RetrieveSubscriptionCustomerRequest{
+		CustomerReferenceCode: "customer-ref",
+	})
+	if err != nil {
+		t.Fatalf("Retrieve customer failed: %v", err)
+	}
+
+	if response.Data.ReferenceCode != "customer-ref" {
+		t.Errorf("Expected reference code customer-ref, got %s", response.Data.ReferenceCode)
+	}
+
+	if response.Data.BillingAddress == nil || response.Data.BillingAddress.District != "Kadikoy" {
+		t.Errorf("Unexpected billing address %+v", response.Data.BillingAddress)
+	}
+}
+
+func TestSubscriptionRetrieve(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		expectedPath := "/v2/subscription/subscriptions/subscription-ref"
+		if r.URL.Path != expectedPath {
+			t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
+		}
+
+		w.Write([]byte(`{
+			"status": "success",
+			"data": {
+				"totalCount": 1,
+				"items": [{
+					"referenceCode": "subscription-ref",
+					"subscriptionStatus": "ACTIVE",
+					"orders": [{
+						"referenceCode": "order-ref",
+						"price": 49.9,
+						"orderStatus": "SUCCESS",
+						"paymentAttempts": [{"paymentId": "12345", "paymentStatus": "SUCCESS"}]
+					}]
+				}]
+			}
+		}`))
+	})
+
+	response, err := client.Subscription.Retrieve(context.Background(), &RetrieveSubscriptionRequest{
+		SubscriptionReferenceCode: "subscription-ref",
+	})
+	if err != nil {
+		t.Fatalf("Retrieve subscription failed: %v", err)
+	}
+
+	subscription := response.Subscription()
+	if subscription == nil {
+		t.Fatal("Subscription should not be nil")
+	}
+
+	if subscription.SubscriptionStatus != SubscriptionStatusActive {
+		t.Errorf("Expected status %s, got %s", SubscriptionStatusActive, subscription.SubscriptionStatus)
+	}
+
+	if len(subscription.Orders) != 1 || len(subscription.Orders[0].PaymentAttempts) != 1 {
+		t.Fatalf("Unexpected orders %+v", subscription.Orders)
+	}
+
+	if subscription.Orders[0].PaymentAttempts[0].PaymentID != "12345" {
+		t.Errorf("Expected payment ID 12345, got %s", subscription.Orders[0].PaymentAttempts[0].PaymentID)
+	}
+}
+
+func TestSubscriptionCancelAndActivate(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"status": "success"}`))
+	})
+
+	if _, err := client.Subscription.Cancel(context.Background(), &CancelSubscriptionRequest{SubscriptionReferenceCode: "subscription-ref"}); err != nil {
+		t.Fatalf("Cancel subscription failed: %v", err)
+	}
+
+	if _, err := client.Subscription.Activate(context.Background(), &ActivateSubscriptionRequest{SubscriptionReferenceCode: "subscription-ref"}); err != nil {
+		t.Fatalf("Activate subscription failed: %v", err)
+	}
+
+	expected := []string{
+		"/v2/subscription/subscriptions/subscription-ref/cancel",
+		"/v2/subscription/subscriptions/subscription-ref/activate",
+	}
+	if len(paths) != len(expected) || paths[0] != expected[0] || paths[1] != expected[1] {
+		t.Errorf("Expected paths %v, got %v", expected, paths)
+	}
+}
+
+func TestSubscriptionUpgrade(t *testing.T) {
+	enabled := true
+
+	tests := []struct {
+		name                 string
+		useTrial             *bool
+		resetRecurrenceCount *bool
+	}{
+		{name: "flags unset"},
+		{name: "flags set", useTrial: &enabled, resetRecurrenceCount: &enabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				expectedPath := "/v2/subscription/subscriptions/subscription-ref/upgrade"
+				if r.URL.Path != expectedPath {
+					t.Errorf("Expected path %s, got %s", expectedPath, r.URL.Path)
+				}
+
+				var body map[string]interface{}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("Failed to decode request: %v", err)
+				}
+
+				if _, ok := body["subscriptionReferenceCode"]; ok {
+					t.Error("Subscription reference code should only be sent in the path")
+				}
+
+				if body["newPricingPlanReferenceCode"] != "plan-ref" {
+					t.Errorf("Expected new pricing plan plan-ref, got %v", body["newPricingPlanReferenceCode"])
+				}
+
+				flags := map[string]*bool{"useTrial": tt.useTrial, "resetRecurrenceCount": tt.resetRecurrenceCount}
+				for key, want := range flags {
+					value, ok := body[key]
+					if ok != (want != nil) {
+						t.Errorf("Expected %s sent=%t, got %v", key, want != nil, value)
+					} else if ok && value != *want {
+						t.Errorf("Expected %s=%t, got %v", key, *want, value)
+					}
+				}
+
+				w.Write([]byte(`{"status": "success", "data": {"referenceCode": "subscription-ref", "pricingPlanReferenceCode": "plan-ref"}}`))
+			})
+
+			_, err := client.Subscription.Upgrade(context.Background(), &UpgradeSubscriptionRequest{
+				SubscriptionReferenceCode:   "subscription-ref",
+				NewPricingPlanReferenceCode: "plan-ref",
+				UpgradePeriod:               SubscriptionUpgradePeriodNow,
+				UseTrial:                    tt.useTrial,
+				ResetRecurrenceCount:        tt.resetRecurrenceCount,
+			})
+			if err != nil {
+				t.Fatalf("Upgrade subscription failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestSubscriptionRetryPayment(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointSubscriptionPaymentRetry {
+			t.Errorf("Expected path %s, got %s", EndpointSubscriptionPaymentRetry, r.URL.Path)
+		}
+
+		var request RetrySubscriptionPaymentRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+
+		if request.ReferenceCode != "order-ref" {
+			t.Errorf("Expected reference code order-ref, got %s", request.ReferenceCode)
+		}
+
+		w.Write([]byte(`{"status": "success"}`))
+	})
+
+	response, err := client.Subscription.RetryPayment(context.Background(), &RetrySubscriptionPaymentRequest{ReferenceCode: "order-ref"})
+	if err != nil {
+		t.Fatalf("Retry payment failed: %v", err)
+	}
+
+	if response.Status != "success" {
+		t.Errorf("Expected status success, got %s", response.Status)
+	}
+}
+
+func TestSubscriptionIterate(t *testing.T) {
+	var requestedPages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointSubscriptionSearch {
+			t.Errorf("Expected path %s, got %s", EndpointSubscriptionSearch, r.URL.Path)
+		}
+
+		if r.URL.Query().Get("subscriptionStatus") != SubscriptionStatusUnpaid {
+			t.Errorf("Expected status filter %s, got %s", SubscriptionStatusUnpaid, r.URL.Query().Get("subscriptionStatus"))
+		}
+
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+
+		w.Write([]byte(`{
+			"status": "success",
+			"data": {
+				"totalCount": 3,
+				"currentPage": ` + page + `,
+				"pageCount": 2,
+				"items": [{"referenceCode": "ref-` + page + `-a"}, {"referenceCode": "ref-` + page + `-b"}]
+			}
+		}`))
+	})
+
+	iterator := client.Subscription.Iterate(&SearchSubscriptionRequest{
+		SubscriptionStatus: SubscriptionStatusUnpaid,
+		Count:              2,
+	})
+
+	var references []string
+	for iterator.Next(context.Background()) {
+		references = append(references, iterator.Subscription().ReferenceCode)
+	}
+	if err := iterator.Err(); err != nil {
+		t.Fatalf("Iteration failed: %v", err)
+	}
+
+	if len(requestedPages) != 2 || requestedPages[0] != "1" || requestedPages[1] != "2" {
+		t.Errorf("Unexpected requested pages %v", requestedPages)
+	}
+
+	if len(references) != 4 || references[3] != "ref-2-b" {
+		t.Errorf("Unexpected references %v", references)
+	}
+}
+
+func TestSubscriptionCheckoutForm(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EndpointSubscriptionCheckoutFormInitialize:
+			w.Write([]byte(`{"status": "success", "token": "form-token", "checkoutFormContent": "<script></script>"}`))
+		case "/v2/subscription/checkoutform/form-token":
+			if r.Method != http.MethodGet {
+				t.Errorf("Expected GET method, got %s", r.Method)
+			}
+			w.Write([]byte(`{"status": "success", "data": {"referenceCode": "subscription-ref", "subscriptionStatus": "ACTIVE"}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	initResponse, err := client.Subscription.InitializeCheckoutForm(ctx, &CreateSubscriptionCheckoutFormRequest{
+		Locale:                    LocaleTR,
+		CallbackURL:               "https://www.merchant.com/callback",
+		PricingPlanReferenceCode:  "plan-ref",
+		SubscriptionInitialStatus: SubscriptionInitialStatusActive,
+	})
+	if err != nil {
+		t.Fatalf("Initialize checkout form failed: %v", err)
+	}
+
+	response, err := client.Subscription.RetrieveCheckoutForm(ctx, &RetrieveSubscriptionCheckoutFormRequest{
+		Token: initResponse.Token,
+	})
+	if err != nil {
+		t.Fatalf("Retrieve checkout form failed: %v", err)
+	}
+
+	if response.Data.ReferenceCode != "subscription-ref" {
+		t.Errorf("Expected reference code subscription-ref, got %s", response.Data.ReferenceCode)
+	}
+}
+
+func TestPaymentItemApproveAll(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointPaymentItemApprove {
+			t.Errorf("Expected path %s, got %s", EndpointPaymentItemApprove, r.URL.Path)
+		}
+
+		var request ApprovePaymentItemRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+
+		if request.PaymentTransactionID == "2" {
+			w.Write([]byte(`{"status": "failure", "errorCode": "10", "errorMessage": "not approvable"}`))
+			return
+		}
+		w.Write([]byte(`{"status": "success", "paymentTransactionId": "` + request.PaymentTransactionID + `"}`))
+	})
+
+	results, err := client.PaymentItem.ApproveAll(context.Background(), &ApprovePaymentItemsRequest{
+		Locale: LocaleTR,
+		ItemTransactions: []ItemTransaction{
+			{ItemID: "BI101", PaymentTransactionID: "1"},
+			{ItemID: "BI102", PaymentTransactionID: "2"},
+		},
+	})
+	if err == nil {
+		t.Error("Expected error when an item fails")
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	if !results[0].Succeeded || results[0].Err != nil {
+		t.Errorf("Expected first item to be approved, got %+v", results[0])
+	}
+
+	if results[1].Succeeded || results[1].Err == nil {
+		t.Errorf("Expected second item to fail, got %+v", results[1])
+	}
+}
+
+func TestPaymentItemDisapproveAll(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointPaymentItemDisapprove {
+			t.Errorf("Expected path %s, got %s", EndpointPaymentItemDisapprove, r.URL.Path)
+		}
+		w.Write([]byte(`{"status": "success"}`))
+	})
+
+	results, err := client.PaymentItem.DisapproveAll(context.Background(), &ApprovePaymentItemsRequest{
+		ItemTransactions: []ItemTransaction{{ItemID: "BI101", PaymentTransactionID: "1"}},
+	})
+	if err != nil {
+		t.Fatalf("Disapprove all failed: %v", err)
+	}
+
+	if len(results) != 1 || !results[0].Succeeded {
+		t.Errorf("Expected disapproval to succeed, got %+v", results)
+	}
+}
+
+func TestReportingRetrievePayoutCompleted(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointReportingSettlementPayoutCompleted {
+			t.Errorf("Expected path %s, got %s", EndpointReportingSettlementPayoutCompleted, r.URL.Path)
+		}
+
+		w.Write([]byte(`{
+			"status": "success",
+			"payoutCompletedTransactions": [
+				{"subMerchantKey": "sub-key", "iban": "TR180006200119000006672315", "payoutAmount": 95.5, "currency": "TRY", "conversionRate": 1}
+			]
+		}`))
+	})
+
+	response, err := client.Reporting.RetrievePayoutCompleted(context.Background(), &RetrieveSettlementReportRequest{
+		Locale: LocaleTR,
+		Date:   "2024-01-31 00:00:00",
+	})
+	if err != nil {
+		t.Fatalf("Retrieve payout completed failed: %v", err)
+	}
+
+	if len(response.PayoutCompletedTransactions) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(response.PayoutCompletedTransactions))
+	}
+
+	row := response.PayoutCompletedTransactions[0]
+	if row.SubMerchantKey != "sub-key" || row.PayoutAmount != "95.5" || row.ConversionRate != "1" {
+		t.Errorf("Unexpected row %+v", row)
+	}
+}
+
+func TestRefundCreateChargedFromMerchant(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointRefundChargedFromMerchant {
+			t.Errorf("Expected path %s, got %s", EndpointRefundChargedFromMerchant, r.URL.Path)
+		}
+
+		w.Write([]byte(`{"status": "success", "paymentId": "12345", "paymentTransactionId": "67890", "price": 0.5}`))
+	})
+
+	response, err := client.Refund.CreateChargedFromMerchant(context.Background(), &RefundChargedFromMerchantRequest{
+		Locale:               LocaleTR,
+		ConversationID:       "123456789",
+		PaymentTransactionID: "67890",
+		Price:                "0.5",
+		Currency:             CurrencyTRY,
+		IP:                   "85.34.78.112",
+	})
+	if err != nil {
+		t.Fatalf("Refund charged from merchant failed: %v", err)
+	}
+
+	if response.PaymentTransactionID != "67890" || response.Price != "0.5" {
+		t.Errorf("Unexpected response %+v", response)
+	}
+}
+
+func TestPeccoInitializeAndCreate(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EndpointPeccoInitialize:
+			w.Write([]byte(`{"status": "success", "htmlContent": "<form></form>", "token": "pecco-token"}`))
+		case EndpointPeccoAuth:
+			var request CreatePeccoPaymentRequest
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+			if request.Token != "pecco-token" {
+				t.Errorf("Expected token pecco-token, got %s", request.Token)
+			}
+			w.Write([]byte(`{"status": "success", "paymentId": "12345"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	initResponse, err := client.Pecco.Initialize(ctx, &CreatePeccoInitializeRequest{
+		Locale:   LocaleTR,
+		Price:    "100",
+		Currency: CurrencyIRR,
+	})
+	if err != nil {
+		t.Fatalf("Pecco initialize failed: %v", err)
+	}
+
+	response, err := client.Pecco.Create(ctx, &CreatePeccoPaymentRequest{
+		Locale: LocaleTR,
+		Token:  initResponse.Token,
+	})
+	if err != nil {
+		t.Fatalf("Pecco auth failed: %v", err)
+	}
+
+	if response.PaymentID != "12345" {
+		t.Errorf("Expected payment ID 12345, got %s", response.PaymentID)
+	}
+}
+
+func TestBKMRetrieveBasic(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != EndpointBKMAuthDetailBasic {
+			t.Errorf("Expected path %s, got %s", EndpointBKMAuthDetailBasic, r.URL.Path)
+		}
+
+		w.Write([]byte(`{"status": "success", "token": "bkm-token", "paymentStatus": "SUCCESS", "paymentId": "12345", "posOrderId": "AP789"}`))
+	})
+
+	response, err := client.BKM.RetrieveBasic(context.Background(), &RetrieveBKMRequest{
+		Locale: LocaleTR,
+		Token:  "bkm-token",
+	})
+	if err != nil {
+		t.Fatalf("BKM basic retrieve failed: %v", err)
+	}
+
+	if response.PaymentID != "12345" || response.PosOrderID != "AP789" {
+		t.Errorf("Unexpected response %+v", response)
+	}
+}
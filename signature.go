@@ -0,0 +1,131 @@
+package iyzipay
+
+import (
+	"crypto/hmac"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrSignatureMismatch is returned when a response signature is missing or does not match its content
+var ErrSignatureMismatch = errors.New("iyzipay: response signature mismatch")
+
+// signedResponse is implemented by responses that carry an iyzico signature
+type signedResponse interface {
+	VerifySignature(secretKey string) error
+}
+
+// verifySignature compares signature with the HMAC of params in iyzico's field order
+func verifySignature(signature, secretKey string, params ...string) error {
+	if signature == "" {
+		return ErrSignatureMismatch
+	}
+	expected := calculateHMACSignature(params, secretKey)
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// signaturePrice normalizes a price the way iyzico does when signing, e.g. "10.50" becomes "10.5",
+// "10.0" becomes "10" and "1.5e+06", as decoded from a JSON number, becomes "1500000"
+func signaturePrice(price string) string {
+	if f, err := strconv.ParseFloat(price, 64); err == nil {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if !strings.Contains(price, ".") {
+		return price
+	}
+	price = strings.TrimRight(price, "0")
+	return strings.TrimSuffix(price, ".")
+}
+
+// VerifySignature verifies the signature of a payment response
+func (r *PaymentResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.PaymentID,
+		r.Currency,
+		r.BasketID,
+		r.ConversationID,
+		signaturePrice(r.PaidPrice),
+		signaturePrice(r.Price),
+	)
+}
+
+// VerifySignature verifies the signature of a 3DS initialize response
+func (r *ThreedsInitializeResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.PaymentID,
+		r.ConversationID,
+	)
+}
+
+// VerifySignature verifies the signature of a checkout form initialize response
+func (r *CheckoutFormInitializeResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.ConversationID,
+		r.Token,
+	)
+}
+
+// VerifySignature verifies the signature of a checkout form response
+func (r *CheckoutFormResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.PaymentStatus,
+		r.PaymentID,
+		r.Currency,
+		r.BasketID,
+		r.ConversationID,
+		signaturePrice(r.PaidPrice),
+		signaturePrice(r.Price),
+		r.Token,
+	)
+}
+
+// VerifySignature verifies the signature of a BKM response
+func (r *BKMResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.PaymentID,
+		r.PaymentStatus,
+		r.BasketID,
+		r.ConversationID,
+		r.Currency,
+		signaturePrice(r.PaidPrice),
+		signaturePrice(r.Price),
+		r.Token,
+	)
+}
+
+// VerifySignature verifies the signature of a basic BKM response
+func (r *BasicBKMResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.PaymentID,
+		r.PaymentStatus,
+		r.ConversationID,
+		r.Currency,
+		signaturePrice(r.PaidPrice),
+		signaturePrice(r.Price),
+		r.Token,
+	)
+}
+
+// VerifySignature verifies the signature of an APM initialize response
+func (r *APMInitializeResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.PaymentID,
+		r.ConversationID,
+		r.RedirectURL,
+	)
+}
+
+// VerifySignature verifies the signature of an APM response
+func (r *APMResponse) VerifySignature(secretKey string) error {
+	return verifySignature(r.Signature, secretKey,
+		r.PaymentID,
+		r.Currency,
+		r.BasketID,
+		r.ConversationID,
+		signaturePrice(r.PaidPrice),
+		signaturePrice(r.Price),
+	)
+}
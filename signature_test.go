@@ -0,0 +1,118 @@
+package iyzipay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSignaturePrice(t *testing.T) {
+	tests := map[string]string{
+		"10":            "10",
+		"10.0":          "10",
+		"10.50":         "10.5",
+		"1.2345":        "1.2345",
+		"100.00":        "100",
+		"1.5e+06":       "1500000",
+		"1.2345678e+07": "12345678",
+		"":              "",
+	}
+
+	for price, expected := range tests {
+		if got := signaturePrice(price); got != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, price, got)
+		}
+	}
+}
+
+func TestPaymentResponseVerifySignature(t *testing.T) {
+	secretKey := "test-secret-key"
+	response := &PaymentResponse{
+		ConversationID: "123",
+		PaymentID:      "12345",
+		Currency:       CurrencyTRY,
+		BasketID:       "B1",
+		PaidPrice:      "1.20",
+		Price:          "1.0",
+	}
+	response.Signature = calculateHMACSignature([]string{"12345", CurrencyTRY, "B1", "123", "1.2", "1"}, secretKey)
+
+	if err := response.VerifySignature(secretKey); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+
+	response.PaidPrice = "0.20"
+	if err := response.VerifySignature(secretKey); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Expected ErrSignatureMismatch for tampered price, got %v", err)
+	}
+
+	response.Signature = ""
+	if err := response.VerifySignature(secretKey); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Expected ErrSignatureMismatch for missing signature, got %v", err)
+	}
+}
+
+func TestCheckoutFormResponseVerifySignature(t *testing.T) {
+	secretKey := "test-secret-key"
+	response := &CheckoutFormResponse{
+		BaseResponse:  BaseResponse{ConversationID: "123"},
+		Token:         "token",
+		PaymentStatus: "SUCCESS",
+		PaymentID:     "12345",
+		Currency:      CurrencyTRY,
+		BasketID:      "B1",
+		PaidPrice:     "1.2",
+		Price:         "1",
+	}
+	response.Signature = calculateHMACSignature([]string{"SUCCESS", "12345", CurrencyTRY, "B1", "123", "1.2", "1", "token"}, secretKey)
+
+	if err := response.VerifySignature(secretKey); err != nil {
+		t.Errorf("Expected valid signature, got %v", err)
+	}
+
+	if err := response.VerifySignature("other-secret-key"); !errors.Is(err, ErrSignatureMismatch) {
+		t.Errorf("Expected ErrSignatureMismatch for wrong key, got %v", err)
+	}
+}
+
+func TestPaymentResponseVerifySignatureHighValue(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		signature := calculateHMACSignature([]string{"12345", CurrencyTRY, "B1", "123", "1500000", "1500000"}, "test-secret-key")
+		fmt.Fprintf(w, `{"status": "success", "conversationId": "123", "paymentId": "12345", "currency": "TRY", "basketId": "B1", "paidPrice": 1500000, "price": 1500000.0, "signature": %q}`, signature)
+	})
+	client.config.VerifySignatures = true
+
+	if _, err := client.Payment.Retrieve(context.Background(), &RetrievePaymentRequest{PaymentID: "12345"}); err != nil {
+		t.Errorf("Expected valid signature for a 7-digit price, got %v", err)
+	}
+}
+
+func TestVerifySignaturesOption(t *testing.T) {
+	signature := calculateHMACSignature([]string{"12345", CurrencyTRY, "B1", "123", "1.2", "1"}, "test-secret-key")
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   error
+	}{
+		{name: "valid signature", signature: signature},
+		{name: "tampered signature", signature: "deadbeef", wantErr: ErrSignatureMismatch},
+		{name: "missing signature", signature: "", wantErr: ErrSignatureMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"status": "success", "conversationId": "123", "paymentId": "12345", "currency": "TRY", "basketId": "B1", "paidPrice": 1.2, "price": 1.0, "signature": %q}`, tt.signature)
+			})
+			client.config.VerifySignatures = true
+
+			_, err := client.Payment.Retrieve(context.Background(), &RetrievePaymentRequest{ConversationID: "123", PaymentID: "12345"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package iyzipay
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// ErrThreedsAuthentication is returned when the bank reports that 3D Secure authentication did not succeed
+var ErrThreedsAuthentication = errors.New("iyzipay: 3D Secure authentication failed")
+
+// ErrConversationMismatch is returned when a callback result belongs to a different conversation
+var ErrConversationMismatch = errors.New("iyzipay: conversation ID mismatch")
+
+// ThreedsMdStatus is the mdStatus value the bank posts to the 3DS callback URL
+type ThreedsMdStatus int
+
+// 3DS mdStatus values
+const (
+	ThreedsMdStatusFailed         ThreedsMdStatus = 0
+	ThreedsMdStatusSuccess        ThreedsMdStatus = 1
+	ThreedsMdStatusNotEnrolled    ThreedsMdStatus = 2
+	ThreedsMdStatusIssuerNotFound ThreedsMdStatus = 3
+	ThreedsMdStatusAttempt        ThreedsMdStatus = 4
+	ThreedsMdStatusUnavailable    ThreedsMdStatus = 5
+	ThreedsMdStatusError          ThreedsMdStatus = 6
+	ThreedsMdStatusSystemError    ThreedsMdStatus = 7
+	ThreedsMdStatusUnknownCard    ThreedsMdStatus = 8
+)
+
+// ThreedsOutcome is the interpreted result of a 3DS callback
+type ThreedsOutcome string
+
+// 3DS outcomes
+const (
+	ThreedsOutcomeAuthenticated ThreedsOutcome = "AUTHENTICATED"
+	ThreedsOutcomeFailed        ThreedsOutcome = "FAILED"
+	ThreedsOutcomeNotEnrolled   ThreedsOutcome = "NOT_ENROLLED"
+	ThreedsOutcomeUnavailable   ThreedsOutcome = "UNAVAILABLE"
+	ThreedsOutcomeError         ThreedsOutcome = "ERROR"
+	ThreedsOutcomeInvalidCard   ThreedsOutcome = "INVALID_CARD"
+)
+
+// Outcome interprets the mdStatus code
+func (s ThreedsMdStatus) Outcome() ThreedsOutcome {
+	switch s {
+	case ThreedsMdStatusSuccess:
+		return ThreedsOutcomeAuthenticated
+	case ThreedsMdStatusFailed:
+		return ThreedsOutcomeFailed
+	case ThreedsMdStatusNotEnrolled, ThreedsMdStatusIssuerNotFound, ThreedsMdStatusAttempt:
+		return ThreedsOutcomeNotEnrolled
+	case ThreedsMdStatusUnavailable:
+		return ThreedsOutcomeUnavailable
+	case ThreedsMdStatusUnknownCard:
+		return ThreedsOutcomeInvalidCard
+	default:
+		return ThreedsOutcomeError
+	}
+}
+
+// ThreedsCallback represents the form fields posted to the 3DS callback URL
+type ThreedsCallback struct {
+	Status           string
+	PaymentID        string
+	ConversationData string
+	ConversationID   string
+	MdStatus         ThreedsMdStatus
+}
+
+// ParseThreedsCallback reads the 3DS callback form fields from r.
+// A missing or malformed mdStatus is reported as ThreedsMdStatusError.
+func ParseThreedsCallback(r *http.Request) (*ThreedsCallback, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, fmt.Errorf("failed to parse 3DS callback: %w", err)
+	}
+
+	callback := &ThreedsCallback{
+		Status:           r.PostFormValue("status"),
+		PaymentID:        r.PostFormValue("paymentId"),
+		ConversationData: r.PostFormValue("conversationData"),
+		ConversationID:   r.PostFormValue("conversationId"),
+		MdStatus:         ThreedsMdStatusError,
+	}
+	if mdStatus, err := strconv.Atoi(r.PostFormValue("mdStatus")); err == nil {
+		callback.MdStatus = ThreedsMdStatus(mdStatus)
+	}
+	return callback, nil
+}
+
+// ThreedsCallbackResult represents the result of handling a 3DS callback
+type ThreedsCallbackResult struct {
+	Callback *ThreedsCallback
+	Outcome  ThreedsOutcome
+	Payment  *PaymentResponse
+	Err      error
+}
+
+// ThreedsCallbackHandler is an http.Handler for the 3DS callback URL. After a successful
+// authentication it completes the payment with ThreedsPayment.Create, or CreateBasic when
+// Basic is set, and verifies the response signature before calling OnSuccess.
+type ThreedsCallbackHandler struct {
+	client *Client
+
+	Basic  bool
+	Locale string
+
+	OnSuccess func(w http.ResponseWriter, r *http.Request, result *ThreedsCallbackResult)
+	OnFailure func(w http.ResponseWriter, r *http.Request, result *ThreedsCallbackResult)
+}
+
+// NewThreedsCallbackHandler creates a 3DS callback handler
+func (c *Client) NewThreedsCallbackHandler() *ThreedsCallbackHandler {
+	return &ThreedsCallbackHandler{client: c}
+}
+
+// ServeHTTP implements http.Handler
+func (h *ThreedsCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	callback, err := ParseThreedsCallback(r)
+	if err != nil {
+		h.fail(w, r, http.StatusBadRequest, &ThreedsCallbackResult{Outcome: ThreedsOutcomeError, Err: err})
+		return
+	}
+
+	result := &ThreedsCallbackResult{Callback: callback, Outcome: callback.MdStatus.Outcome()}
+	if callback.Status != "success" || result.Outcome != ThreedsOutcomeAuthenticated {
+		result.Err = fmt.Errorf("%w: mdStatus %d", ErrThreedsAuthentication, callback.MdStatus)
+		h.fail(w, r, http.StatusPaymentRequired, result)
+		return
+	}
+
+	request := &ThreedsPaymentRequest{
+		Locale:           h.Locale,
+		ConversationID:   callback.ConversationID,
+		PaymentID:        callback.PaymentID,
+		ConversationData: callback.ConversationData,
+	}
+
+	var payment *PaymentResponse
+	if h.Basic {
+		payment, err = h.client.ThreedsPayment.CreateBasic(r.Context(), request)
+	} else {
+		payment, err = h.client.ThreedsPayment.Create(r.Context(), request)
+	}
+	result.Payment = payment
+	if err != nil {
+		result.Err = err
+		h.fail(w, r, http.StatusPaymentRequired, result)
+		return
+	}
+
+	if err := payment.VerifySignature(h.client.config.SecretKey); err != nil {
+		result.Err = err
+		h.fail(w, r, http.StatusPaymentRequired, result)
+		return
+	}
+
+	if payment.ConversationID != callback.ConversationID || payment.PaymentID != callback.PaymentID {
+		result.Err = ErrConversationMismatch
+		h.fail(w, r, http.StatusPaymentRequired, result)
+		return
+	}
+
+	if h.OnSuccess != nil {
+		h.OnSuccess(w, r, result)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// fail reports a failed callback to OnFailure, or responds with status when it is not set
+func (h *ThreedsCallbackHandler) fail(w http.ResponseWriter, r *http.Request, status int, result *ThreedsCallbackResult) {
+	if h.OnFailure != nil {
+		h.OnFailure(w, r, result)
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
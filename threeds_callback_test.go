@@ -0,0 +1,123 @@
+package iyzipay
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestThreedsMdStatusOutcome(t *testing.T) {
+	tests := map[ThreedsMdStatus]ThreedsOutcome{
+		0:  ThreedsOutcomeFailed,
+		1:  ThreedsOutcomeAuthenticated,
+		2:  ThreedsOutcomeNotEnrolled,
+		4:  ThreedsOutcomeNotEnrolled,
+		5:  ThreedsOutcomeUnavailable,
+		7:  ThreedsOutcomeError,
+		8:  ThreedsOutcomeInvalidCard,
+		-1: ThreedsOutcomeError,
+	}
+
+	for mdStatus, expected := range tests {
+		if got := mdStatus.Outcome(); got != expected {
+			t.Errorf("Expected %s for mdStatus %d, got %s", expected, mdStatus, got)
+		}
+	}
+}
+
+// postCallback posts form to a callback handler the way the payment page does
+func postCallback(h http.Handler, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestThreedsCallbackHandler(t *testing.T) {
+	signature := calculateHMACSignature([]string{"12345", CurrencyTRY, "B1", "123", "1.2", "1"}, "test-secret-key")
+
+	tests := []struct {
+		name        string
+		mdStatus    string
+		signature   string
+		wantOutcome ThreedsOutcome
+		wantErr     error
+		wantCalls   int
+	}{
+		{name: "completed", mdStatus: "1", signature: signature, wantOutcome: ThreedsOutcomeAuthenticated, wantCalls: 1},
+		{name: "authentication failed", mdStatus: "0", signature: signature, wantOutcome: ThreedsOutcomeFailed, wantErr: ErrThreedsAuthentication},
+		{name: "invalid signature", mdStatus: "1", signature: "deadbeef", wantOutcome: ThreedsOutcomeAuthenticated, wantErr: ErrSignatureMismatch, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if r.URL.Path != EndpointPayment3DSecureAuth {
+					t.Errorf("Expected path %s, got %s", EndpointPayment3DSecureAuth, r.URL.Path)
+				}
+				fmt.Fprintf(w, `{"status": "success", "conversationId": "123", "paymentId": "12345", "currency": "TRY", "basketId": "B1", "paidPrice": "1.2", "price": "1.0", "signature": %q}`, tt.signature)
+			})
+
+			var result *ThreedsCallbackResult
+			var succeeded bool
+			handler := client.NewThreedsCallbackHandler()
+			handler.OnSuccess = func(w http.ResponseWriter, r *http.Request, res *ThreedsCallbackResult) {
+				succeeded = true
+				result = res
+			}
+			handler.OnFailure = func(w http.ResponseWriter, r *http.Request, res *ThreedsCallbackResult) {
+				result = res
+			}
+
+			postCallback(handler, url.Values{
+				"status":           {"success"},
+				"paymentId":        {"12345"},
+				"conversationData": {"data"},
+				"conversationId":   {"123"},
+				"mdStatus":         {tt.mdStatus},
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d API calls, got %d", tt.wantCalls, calls)
+			}
+
+			if result == nil {
+				t.Fatal("Expected a callback to be called")
+			}
+
+			if result.Outcome != tt.wantOutcome {
+				t.Errorf("Expected outcome %s, got %s", tt.wantOutcome, result.Outcome)
+			}
+
+			if !errors.Is(result.Err, tt.wantErr) {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, result.Err)
+			}
+
+			if succeeded != (tt.wantErr == nil) {
+				t.Errorf("Expected success=%t, got %t", tt.wantErr == nil, succeeded)
+			}
+		})
+	}
+}
+
+func TestThreedsCallbackHandlerDefaultFailure(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no API call")
+	})
+
+	rec := postCallback(client.NewThreedsCallbackHandler(), url.Values{
+		"status":   {"failure"},
+		"mdStatus": {"0"},
+	})
+
+	if rec.Code != http.StatusPaymentRequired {
+		t.Errorf("Expected status %d, got %d", http.StatusPaymentRequired, rec.Code)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/url"
 	"reflect"
 	"sort"
 	"strconv"
@@ -222,6 +223,48 @@ func mapToPKI(v reflect.Value) string {
 	return fmt.Sprintf("[%s]", strings.Join(pairs, ","))
 }
 
+// buildEndpoint replaces {placeholder} segments in endpoint with path-escaped values
+func buildEndpoint(endpoint string, params map[string]string) (string, error) {
+	for key, value := range params {
+		placeholder := "{" + key + "}"
+		if !strings.Contains(endpoint, placeholder) {
+			return "", fmt.Errorf("endpoint %s has no placeholder %s", endpoint, placeholder)
+		}
+		if strings.TrimSpace(value) == "" {
+			return "", fmt.Errorf("%s cannot be empty", key)
+		}
+		endpoint = strings.ReplaceAll(endpoint, placeholder, url.PathEscape(value))
+	}
+	return endpoint, nil
+}
+
+// withPagination appends page and count query parameters to endpoint
+func withPagination(endpoint string, pagination *Pagination) string {
+	if pagination == nil {
+		return endpoint
+	}
+
+	query := url.Values{}
+	if pagination.Page > 0 {
+		query.Set("page", strconv.Itoa(pagination.Page))
+	}
+	if pagination.Count > 0 {
+		query.Set("count", strconv.Itoa(pagination.Count))
+	}
+	if len(query) == 0 {
+		return endpoint
+	}
+	return endpoint + "?" + query.Encode()
+}
+
+// stripQuery returns endpoint without its query string
+func stripQuery(endpoint string) string {
+	if i := strings.Index(endpoint, "?"); i >= 0 {
+		return endpoint[:i]
+	}
+	return endpoint
+}
+
 // mergeObjects merges two maps/objects
 func mergeObjects(obj1, obj2 map[string]interface{}) map[string]interface{} {
 	merged := make(map[string]interface{})
@@ -455,4 +455,41 @@ func compareStructs(a, b interface{}) bool {
 	}
 	
 	return true
-}
\ No newline at end of file
+}
+
+func TestBuildEndpoint(t *testing.T) {
+	endpoint, err := buildEndpoint(EndpointSubscriptionProduct, map[string]string{
+		"productReferenceCode": "abc/../def",
+	})
+	if err != nil {
+		t.Fatalf("buildEndpoint() error = %v", err)
+	}
+
+	expected := "/v2/subscription/products/abc%2F..%2Fdef"
+	if endpoint != expected {
+		t.Errorf("buildEndpoint() = %s, want %s", endpoint, expected)
+	}
+
+	if _, err := buildEndpoint(EndpointSubscriptionProduct, map[string]string{
+		"productReferenceCode": "",
+	}); err == nil {
+		t.Error("buildEndpoint() should fail for empty value")
+	}
+
+	if _, err := buildEndpoint(EndpointSubscriptionProducts, map[string]string{
+		"productReferenceCode": "abc",
+	}); err == nil {
+		t.Error("buildEndpoint() should fail for missing placeholder")
+	}
+}
+
+func TestWithPagination(t *testing.T) {
+	result := withPagination(EndpointSubscriptionProducts, &Pagination{Page: 2, Count: 50})
+	if result != EndpointSubscriptionProducts+"?count=50&page=2" {
+		t.Errorf("withPagination() = %s", result)
+	}
+
+	if stripQuery(result) != EndpointSubscriptionProducts {
+		t.Errorf("stripQuery() = %s, want %s", stripQuery(result), EndpointSubscriptionProducts)
+	}
+}
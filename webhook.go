@@ -0,0 +1,326 @@
+package iyzipay
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// defaultWebhookMaxBodyBytes limits the size of a notification body
+const defaultWebhookMaxBodyBytes = 64 << 10
+
+// WebhookNotification represents the raw body of an iyzico merchant notification
+type WebhookNotification struct {
+	IyziEventType             string `json:"iyziEventType"`
+	IyziEventTime             int64  `json:"iyziEventTime"`
+	IyziReferenceCode         string `json:"iyziReferenceCode"`
+	MerchantID                string `json:"merchantId"`
+	PaymentID                 string `json:"paymentId"`
+	PaymentConversationID     string `json:"paymentConversationId"`
+	Status                    string `json:"status"`
+	IyziPaymentID             string `json:"iyziPaymentId"`
+	Token                     string `json:"token"`
+	SubscriptionReferenceCode string `json:"subscriptionReferenceCode"`
+	OrderReferenceCode        string `json:"orderReferenceCode"`
+	CustomerReferenceCode     string `json:"customerReferenceCode"`
+}
+
+// webhookValue decodes a JSON string, or keeps the literal text of a JSON number so that
+// numeric IDs are signed exactly as iyzico sent them
+type webhookValue string
+
+// UnmarshalJSON implements json.Unmarshaler
+func (v *webhookValue) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*v = webhookValue(s)
+		return nil
+	}
+	if string(data) == "null" {
+		*v = ""
+		return nil
+	}
+	*v = webhookValue(data)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. IDs sent as JSON numbers are kept verbatim.
+func (n *WebhookNotification) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		IyziEventType             webhookValue `json:"iyziEventType"`
+		IyziEventTime             webhookValue `json:"iyziEventTime"`
+		IyziReferenceCode         webhookValue `json:"iyziReferenceCode"`
+		MerchantID                webhookValue `json:"merchantId"`
+		PaymentID                 webhookValue `json:"paymentId"`
+		PaymentConversationID     webhookValue `json:"paymentConversationId"`
+		Status                    webhookValue `json:"status"`
+		IyziPaymentID             webhookValue `json:"iyziPaymentId"`
+		Token                     webhookValue `json:"token"`
+		SubscriptionReferenceCode webhookValue `json:"subscriptionReferenceCode"`
+		OrderReferenceCode        webhookValue `json:"orderReferenceCode"`
+		CustomerReferenceCode     webhookValue `json:"customerReferenceCode"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	eventTime, _ := strconv.ParseInt(string(raw.IyziEventTime), 10, 64)
+	*n = WebhookNotification{
+		IyziEventType:             string(raw.IyziEventType),
+		IyziEventTime:             eventTime,
+		IyziReferenceCode:         string(raw.IyziReferenceCode),
+		MerchantID:                string(raw.MerchantID),
+		PaymentID:                 string(raw.PaymentID),
+		PaymentConversationID:     string(raw.PaymentConversationID),
+		Status:                    string(raw.Status),
+		IyziPaymentID:             string(raw.IyziPaymentID),
+		Token:                     string(raw.Token),
+		SubscriptionReferenceCode: string(raw.SubscriptionReferenceCode),
+		OrderReferenceCode:        string(raw.OrderReferenceCode),
+		CustomerReferenceCode:     string(raw.CustomerReferenceCode),
+	}
+	return nil
+}
+
+// IsSubscription reports whether the notification is about a subscription order
+func (n *WebhookNotification) IsSubscription() bool {
+	return n.SubscriptionReferenceCode != "" || strings.HasPrefix(n.IyziEventType, "subscription.")
+}
+
+// IsCheckoutForm reports whether the notification is about a hosted payment page such as the checkout form
+func (n *WebhookNotification) IsCheckoutForm() bool {
+	return n.Token != ""
+}
+
+// VerifySignature verifies the X-IYZ-SIGNATURE-V3 header value of the notification
+func (n *WebhookNotification) VerifySignature(secretKey, signature string) error {
+	if signature == "" {
+		return ErrSignatureMismatch
+	}
+
+	var message string
+	switch {
+	case n.IsSubscription():
+		message = n.MerchantID + secretKey + n.IyziEventType + n.SubscriptionReferenceCode + n.OrderReferenceCode + n.CustomerReferenceCode
+	case n.IsCheckoutForm():
+		message = secretKey + n.IyziEventType + n.IyziPaymentID + n.Token + n.PaymentConversationID + n.Status
+	default:
+		message = secretKey + n.IyziEventType + n.PaymentID + n.PaymentConversationID + n.Status
+	}
+
+	h := hmac.New(sha256.New, []byte(secretKey))
+	h.Write([]byte(message))
+	expected := hex.EncodeToString(h.Sum(nil))
+
+	if !hmac.Equal([]byte(strings.ToLower(signature)), []byte(expected)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// PaymentWebhookEvent represents a payment status notification
+type PaymentWebhookEvent struct {
+	EventType             string
+	EventTime             int64
+	ReferenceCode         string
+	MerchantID            string
+	PaymentID             string
+	PaymentConversationID string
+	Status                string
+	Token                 string
+}
+
+// RefundWebhookEvent represents a refund or cancel notification
+type RefundWebhookEvent struct {
+	EventType             string
+	EventTime             int64
+	ReferenceCode         string
+	MerchantID            string
+	PaymentID             string
+	PaymentConversationID string
+	Status                string
+}
+
+// APMWebhookEvent represents an alternative payment method result notification
+type APMWebhookEvent struct {
+	EventType             string
+	EventTime             int64
+	ReferenceCode         string
+	MerchantID            string
+	PaymentID             string
+	PaymentConversationID string
+	Status                string
+}
+
+// SubscriptionWebhookEvent represents a subscription order notification
+type SubscriptionWebhookEvent struct {
+	EventType                 string
+	EventTime                 int64
+	ReferenceCode             string
+	MerchantID                string
+	SubscriptionReferenceCode string
+	OrderReferenceCode        string
+	CustomerReferenceCode     string
+}
+
+// IsSuccess reports whether the subscription order was paid
+func (e *SubscriptionWebhookEvent) IsSuccess() bool {
+	return e.EventType == WebhookEventSubscriptionOrderSuccess
+}
+
+// WebhookHandler is an http.Handler that receives iyzico merchant notifications.
+// It verifies the X-IYZ-SIGNATURE-V3 header and dispatches typed events to the
+// registered callbacks. Returning an error from a callback responds with 500 so
+// that iyzico delivers the notification again.
+type WebhookHandler struct {
+	secretKey string
+
+	// MerchantID is used to verify subscription notifications that do not carry it
+	MerchantID string
+	// MaxBodyBytes limits the notification body size, defaulting to 64 KiB
+	MaxBodyBytes int64
+
+	OnPayment      func(ctx context.Context, event *PaymentWebhookEvent) error
+	OnRefund       func(ctx context.Context, event *RefundWebhookEvent) error
+	OnAPM          func(ctx context.Context, event *APMWebhookEvent) error
+	OnSubscription func(ctx context.Context, event *SubscriptionWebhookEvent) error
+	// OnError is called when a notification is rejected or a callback fails
+	OnError func(r *http.Request, err error)
+}
+
+// NewWebhookHandler creates a webhook handler that verifies notifications with the client's secret key
+func (c *Client) NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{secretKey: c.config.SecretKey}
+}
+
+// ServeHTTP implements http.Handler
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.reject(w, r, http.StatusMethodNotAllowed, errors.New("iyzipay: webhook method not allowed"))
+		return
+	}
+
+	maxBytes := h.MaxBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = defaultWebhookMaxBodyBytes
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			h.reject(w, r, http.StatusRequestEntityTooLarge, err)
+			return
+		}
+		h.reject(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	var notification WebhookNotification
+	if err := json.Unmarshal(body, &notification); err != nil || notification.IyziEventType == "" {
+		h.reject(w, r, http.StatusBadRequest, errors.New("iyzipay: malformed webhook notification"))
+		return
+	}
+	if notification.MerchantID == "" {
+		notification.MerchantID = h.MerchantID
+	}
+
+	if err := notification.VerifySignature(h.secretKey, r.Header.Get(HeaderWebhookSignatureV3)); err != nil {
+		h.reject(w, r, http.StatusUnauthorized, err)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), &notification); err != nil {
+		h.reject(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch decodes the notification into its typed event and calls the matching callback.
+// Notifications without a registered callback are acknowledged and ignored.
+func (h *WebhookHandler) dispatch(ctx context.Context, n *WebhookNotification) error {
+	switch {
+	case n.IsSubscription():
+		if h.OnSubscription == nil {
+			return nil
+		}
+		return h.OnSubscription(ctx, &SubscriptionWebhookEvent{
+			EventType:                 n.IyziEventType,
+			EventTime:                 n.IyziEventTime,
+			ReferenceCode:             n.IyziReferenceCode,
+			MerchantID:                n.MerchantID,
+			SubscriptionReferenceCode: n.SubscriptionReferenceCode,
+			OrderReferenceCode:        n.OrderReferenceCode,
+			CustomerReferenceCode:     n.CustomerReferenceCode,
+		})
+	case n.IyziEventType == WebhookEventRefund || n.IyziEventType == WebhookEventCancel:
+		if h.OnRefund == nil {
+			return nil
+		}
+		return h.OnRefund(ctx, &RefundWebhookEvent{
+			EventType:             n.IyziEventType,
+			EventTime:             n.IyziEventTime,
+			ReferenceCode:         n.IyziReferenceCode,
+			MerchantID:            n.MerchantID,
+			PaymentID:             n.paymentID(),
+			PaymentConversationID: n.PaymentConversationID,
+			Status:                n.Status,
+		})
+	case strings.HasPrefix(n.IyziEventType, "APM"):
+		if h.OnAPM == nil {
+			return nil
+		}
+		return h.OnAPM(ctx, &APMWebhookEvent{
+			EventType:             n.IyziEventType,
+			EventTime:             n.IyziEventTime,
+			ReferenceCode:         n.IyziReferenceCode,
+			MerchantID:            n.MerchantID,
+			PaymentID:             n.paymentID(),
+			PaymentConversationID: n.PaymentConversationID,
+			Status:                n.Status,
+		})
+	default:
+		if h.OnPayment == nil {
+			return nil
+		}
+		return h.OnPayment(ctx, &PaymentWebhookEvent{
+			EventType:             n.IyziEventType,
+			EventTime:             n.IyziEventTime,
+			ReferenceCode:         n.IyziReferenceCode,
+			MerchantID:            n.MerchantID,
+			PaymentID:             n.paymentID(),
+			PaymentConversationID: n.PaymentConversationID,
+			Status:                n.Status,
+			Token:                 n.Token,
+		})
+	}
+}
+
+// paymentID returns the payment ID, which hosted payment notifications send as iyziPaymentId
+func (n *WebhookNotification) paymentID() string {
+	if n.PaymentID != "" {
+		return n.PaymentID
+	}
+	return n.IyziPaymentID
+}
+
+// reject responds with status and reports err to OnError
+func (h *WebhookHandler) reject(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.OnError != nil {
+		h.OnError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
@@ -0,0 +1,176 @@
+package iyzipay
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func webhookSignature(secretKey, message string) string {
+	h := hmac.New(sha256.New, []byte(secretKey))
+	h.Write([]byte(message))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func newTestWebhookHandler() *WebhookHandler {
+	client := NewClient(&Config{
+		APIKey:    "test-api-key",
+		SecretKey: "test-secret-key",
+		BaseURL:   "https://sandbox-api.iyzipay.com",
+	})
+	return client.NewWebhookHandler()
+}
+
+func serveWebhook(h http.Handler, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set(HeaderWebhookSignatureV3, signature)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWebhookHandlerPayment(t *testing.T) {
+	handler := newTestWebhookHandler()
+
+	var received *PaymentWebhookEvent
+	handler.OnPayment = func(ctx context.Context, event *PaymentWebhookEvent) error {
+		received = event
+		return nil
+	}
+
+	body := `{"paymentConversationId":"123","merchantId":1000,"paymentId":12345,"status":"SUCCESS","iyziReferenceCode":"ref","iyziEventType":"THREE_DS_AUTH","iyziEventTime":1700000000000}`
+	signature := webhookSignature("test-secret-key", "test-secret-key"+"THREE_DS_AUTH"+"12345"+"123"+"SUCCESS")
+
+	rec := serveWebhook(handler, body, signature)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	if received == nil {
+		t.Fatal("Expected payment callback to be called")
+	}
+
+	if received.PaymentID != "12345" || received.PaymentConversationID != "123" || received.Status != "SUCCESS" {
+		t.Errorf("Unexpected event %+v", received)
+	}
+}
+
+func TestWebhookHandlerLargeNumericIDs(t *testing.T) {
+	handler := newTestWebhookHandler()
+
+	var received *PaymentWebhookEvent
+	handler.OnPayment = func(ctx context.Context, event *PaymentWebhookEvent) error {
+		received = event
+		return nil
+	}
+
+	body := `{"paymentConversationId":"123","merchantId":1234567,"paymentId":17498765,"status":"SUCCESS","iyziEventType":"API_AUTH","iyziEventTime":1700000000000}`
+	signature := webhookSignature("test-secret-key", "test-secret-key"+"API_AUTH"+"17498765"+"123"+"SUCCESS")
+
+	if rec := serveWebhook(handler, body, signature); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	if received == nil || received.PaymentID != "17498765" || received.MerchantID != "1234567" {
+		t.Errorf("Unexpected event %+v", received)
+	}
+
+	if received != nil && received.EventTime != 1700000000000 {
+		t.Errorf("Expected event time 1700000000000, got %d", received.EventTime)
+	}
+}
+
+func TestWebhookHandlerCheckoutForm(t *testing.T) {
+	handler := newTestWebhookHandler()
+
+	var received *PaymentWebhookEvent
+	handler.OnPayment = func(ctx context.Context, event *PaymentWebhookEvent) error {
+		received = event
+		return nil
+	}
+
+	body := `{"paymentConversationId":"123","iyziPaymentId":12345,"token":"tok","status":"SUCCESS","iyziEventType":"CHECKOUT_FORM_AUTH"}`
+	signature := webhookSignature("test-secret-key", "test-secret-key"+"CHECKOUT_FORM_AUTH"+"12345"+"tok"+"123"+"SUCCESS")
+
+	if rec := serveWebhook(handler, body, signature); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	if received == nil || received.PaymentID != "12345" || received.Token != "tok" {
+		t.Errorf("Unexpected event %+v", received)
+	}
+}
+
+func TestWebhookHandlerSubscription(t *testing.T) {
+	handler := newTestWebhookHandler()
+	handler.MerchantID = "1000"
+
+	var received *SubscriptionWebhookEvent
+	handler.OnSubscription = func(ctx context.Context, event *SubscriptionWebhookEvent) error {
+		received = event
+		return nil
+	}
+
+	body := `{"orderReferenceCode":"order","customerReferenceCode":"customer","subscriptionReferenceCode":"sub","iyziReferenceCode":"ref","iyziEventType":"subscription.order.success","iyziEventTime":1700000000000}`
+	signature := webhookSignature("test-secret-key", "1000"+"test-secret-key"+"subscription.order.success"+"sub"+"order"+"customer")
+
+	if rec := serveWebhook(handler, body, signature); rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+
+	if received == nil || !received.IsSuccess() || received.SubscriptionReferenceCode != "sub" {
+		t.Errorf("Unexpected event %+v", received)
+	}
+}
+
+func TestWebhookHandlerReject(t *testing.T) {
+	body := `{"paymentConversationId":"123","paymentId":"12345","status":"SUCCESS","iyziEventType":"REFUND"}`
+	signature := webhookSignature("test-secret-key", "test-secret-key"+"REFUND"+"12345"+"123"+"SUCCESS")
+
+	tests := []struct {
+		name        string
+		method      string
+		body        string
+		signature   string
+		callbackErr error
+		wantStatus  int
+	}{
+		{name: "wrong method", method: http.MethodGet, body: body, signature: signature, wantStatus: http.StatusMethodNotAllowed},
+		{name: "malformed body", method: http.MethodPost, body: `not json`, signature: signature, wantStatus: http.StatusBadRequest},
+		{name: "missing signature", method: http.MethodPost, body: body, wantStatus: http.StatusUnauthorized},
+		{name: "tampered body", method: http.MethodPost, body: strings.Replace(body, "SUCCESS", "FAILURE", 1), signature: signature, wantStatus: http.StatusUnauthorized},
+		{name: "callback error", method: http.MethodPost, body: body, signature: signature, callbackErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+		{name: "accepted", method: http.MethodPost, body: body, signature: signature, wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestWebhookHandler()
+
+			var called bool
+			handler.OnRefund = func(ctx context.Context, event *RefundWebhookEvent) error {
+				called = true
+				return tt.callbackErr
+			}
+
+			req := httptest.NewRequest(tt.method, "/webhook", strings.NewReader(tt.body))
+			req.Header.Set(HeaderWebhookSignatureV3, tt.signature)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			if wantCalled := tt.wantStatus == http.StatusOK || tt.callbackErr != nil; called != wantCalled {
+				t.Errorf("Expected callback called=%t, got %t", wantCalled, called)
+			}
+		})
+	}
+}